@@ -2,6 +2,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/lensesio/lenses-go/pkg/alert"
 	"github.com/lensesio/lenses-go/pkg/api"
 	"github.com/lensesio/lenses-go/pkg/audit"
+	"github.com/lensesio/lenses-go/pkg/broker"
 	config "github.com/lensesio/lenses-go/pkg/configs"
 	"github.com/lensesio/lenses-go/pkg/connection"
 	"github.com/lensesio/lenses-go/pkg/connector"
@@ -21,6 +23,7 @@ import (
 	"github.com/lensesio/lenses-go/pkg/export"
 	imports "github.com/lensesio/lenses-go/pkg/import"
 	"github.com/lensesio/lenses-go/pkg/initcontainer"
+	"github.com/lensesio/lenses-go/pkg/landscape"
 	"github.com/lensesio/lenses-go/pkg/license"
 	"github.com/lensesio/lenses-go/pkg/logs"
 	"github.com/lensesio/lenses-go/pkg/management"
@@ -68,6 +71,11 @@ func setup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// the landscape files are validated offline.
+	if topLevelSubCmd == "landscape" && (cmd.Name() == "validate" || cmd.Name() == "schemas") {
+		return nil
+	}
+
 	// it's not nil, if context does not exist then it would throw an error.
 	currentConfig := config.Manager.Config.GetCurrent()
 	for !ok {
@@ -156,6 +164,9 @@ func main() {
 	// Audit channels
 	app.AddCommand(audit.NewGetAuditChannelsCommand())
 
+	//Brokers
+	app.AddCommand(broker.NewBrokersGroupCommand())
+
 	//Config
 	app.AddCommand(config.NewGetConfigsCommand())
 	app.AddCommand(config.NewGetModeCommand())
@@ -173,6 +184,9 @@ func main() {
 	//Import
 	app.AddCommand(imports.NewImportGroupCommand())
 
+	//Landscape
+	app.AddCommand(landscape.NewLandscapeGroupCommand())
+
 	//License
 	app.AddCommand(license.NewLicenseGroupCommand())
 
@@ -211,6 +225,7 @@ func main() {
 
 	//SQL
 	app.AddCommand(sql.NewLiveLSQLCommand())
+	app.AddCommand(sql.NewSQLGroupCommand())
 
 	//User
 	app.AddCommand(user.NewGetConfigurationContextsCommand())
@@ -237,6 +252,10 @@ func main() {
 
 	if err := app.Run(os.Stdout, os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		// a non-empty import plan of `--detailed-exitcode` or a landscape drift is not a failure.
+		if errors.Is(err, imports.ErrPlanNotEmpty) || errors.Is(err, imports.ErrDriftDetected) {
+			os.Exit(2)
+		}
 		os.Exit(1)
 	}
 }
@@ -1164,6 +1164,60 @@ type CreateTopicPayload struct {
 	Configs     KV     `json:"configs" yaml:"configs"`
 }
 
+// TopicConfigKeys are the keys of the topic configs of Apache Kafka, see `IsValidTopicConfigKey`.
+var TopicConfigKeys = []string{
+	"cleanup.policy",
+	"compression.gzip.level",
+	"compression.lz4.level",
+	"compression.type",
+	"compression.zstd.level",
+	"delete.retention.ms",
+	"file.delete.delay.ms",
+	"flush.messages",
+	"flush.ms",
+	"follower.replication.throttled.replicas",
+	"index.interval.bytes",
+	"leader.replication.throttled.replicas",
+	"local.retention.bytes",
+	"local.retention.ms",
+	"max.compaction.lag.ms",
+	"max.message.bytes",
+	"message.downconversion.enable",
+	"message.format.version",
+	"message.timestamp.after.max.ms",
+	"message.timestamp.before.max.ms",
+	"message.timestamp.difference.max.ms",
+	"message.timestamp.type",
+	"min.cleanable.dirty.ratio",
+	"min.compaction.lag.ms",
+	"min.insync.replicas",
+	"preallocate",
+	"remote.storage.enable",
+	"retention.bytes",
+	"retention.ms",
+	"segment.bytes",
+	"segment.index.bytes",
+	"segment.jitter.ms",
+	"segment.ms",
+	"unclean.leader.election.enable",
+}
+
+// IsValidTopicConfigKey checks if a key is a topic config of Apache Kafka,
+// the configs of the Confluent Platform, prefixed with "confluent.", are accepted as they are.
+func IsValidTopicConfigKey(key string) bool {
+	if strings.HasPrefix(key, "confluent.") {
+		return true
+	}
+
+	for _, k := range TopicConfigKeys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CreateTopic creates a topic.
 //
 // topicName, string, Required.
@@ -1344,6 +1398,30 @@ func (topic *Topic) GetTopicAsRequest(config KV) CreateTopicPayload {
 	}
 }
 
+// ConfigOverrides returns the topic configs which are not set to their default value.
+func (topic *Topic) ConfigOverrides() KV {
+	overrides := make(KV)
+
+	for _, kv := range topic.Configs {
+		if val, ok := kv["isDefault"]; ok {
+			if val.(bool) == false {
+				var name, value string
+
+				if val, ok := kv["name"]; ok {
+					name = val.(string)
+				}
+
+				if val, ok := kv["originalValue"]; ok {
+					value = val.(string)
+				}
+				overrides[name] = value
+			}
+		}
+	}
+
+	return overrides
+}
+
 // ConsumersGroup describes the data that the `Topic`'s  `ConsumersGroup` field contains.
 type ConsumersGroup struct {
 	ID          string              `json:"id"`
@@ -1400,12 +1478,33 @@ type ConsumerCoordinator struct {
 	Rack string `json:"rack"`
 }
 
+// UnderReplicatedPartitions returns the partitions of the topic which have fewer in-sync replicas than replicas.
+func (topic *Topic) UnderReplicatedPartitions() (partitions []PartitionMessage) {
+	for _, p := range topic.MessagesPerPartition {
+		if p.IsUnderReplicated() {
+			partitions = append(partitions, p)
+		}
+	}
+
+	return
+}
+
 // PartitionMessage describes a partition's message response data.
 type PartitionMessage struct {
 	Partition int   `json:"partition"`
 	Messages  int64 `json:"messages"`
 	Begin     int64 `json:"begin"`
 	End       int64 `json:"end"`
+	// Leader is the broker id of the partition's leader, -1 when the partition has no leader
+	// and nil when the response does not include it.
+	Leader         *int  `json:"leader,omitempty"`
+	Replicas       []int `json:"replicas,omitempty"`
+	InSyncReplicas []int `json:"isr,omitempty"`
+}
+
+// IsUnderReplicated reports whether the partition has fewer in-sync replicas than replicas.
+func (p PartitionMessage) IsUnderReplicated() bool {
+	return len(p.InSyncReplicas) < len(p.Replicas)
 }
 
 // GetTopic returns a topic's information, a `lenses.Topic` value.
@@ -1444,6 +1543,41 @@ type CreateProcessorFilePayload struct {
 	ProcessorID string `json:"processorId,omitempty" yaml:"processorId"` //not required
 }
 
+// ProcessorMetadataFilePayload holds the data of a processor whose SQL is kept in a separate `.sql` file.
+type ProcessorMetadataFilePayload struct {
+	Name        string `json:"name" yaml:"name"` // defaults to the name of the `.sql` file if not set.
+	Runners     int    `json:"runnerCount" yaml:"runnerCount"`
+	ClusterName string `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+	Namespace   string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Pipeline    string `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+	ProcessorID string `json:"processorId,omitempty" yaml:"processorId,omitempty"`
+}
+
+// Metadata returns the processor without its SQL, see `ProcessorMetadataFilePayload`.
+func (p CreateProcessorFilePayload) Metadata() ProcessorMetadataFilePayload {
+	return ProcessorMetadataFilePayload{
+		Name:        p.Name,
+		Runners:     p.Runners,
+		ClusterName: p.ClusterName,
+		Namespace:   p.Namespace,
+		Pipeline:    p.Pipeline,
+		ProcessorID: p.ProcessorID,
+	}
+}
+
+// WithSQL returns the processor of the metadata and its SQL.
+func (m ProcessorMetadataFilePayload) WithSQL(sql string) CreateProcessorFilePayload {
+	return CreateProcessorFilePayload{
+		Name:        m.Name,
+		SQL:         sql,
+		Runners:     m.Runners,
+		ClusterName: m.ClusterName,
+		Namespace:   m.Namespace,
+		Pipeline:    m.Pipeline,
+		ProcessorID: m.ProcessorID,
+	}
+}
+
 // CreateProcessorRequestPayload holds the data to be sent from `CreateProcessor`.
 type CreateProcessorRequestPayload struct {
 	Name        string `json:"name" yaml:"name"` // required
@@ -1858,6 +1992,40 @@ type CreateUpdateConnectorPayload struct {
 	Config      ConnectorConfig `yaml:"config"`
 }
 
+// ConnectConnectorPayload is a connector in the format of the Kafka Connect REST API, `{"name": .., "config": {..}}`,
+// it has no cluster.
+type ConnectConnectorPayload struct {
+	Name   string          `json:"name" yaml:"name"`
+	Config ConnectorConfig `json:"config" yaml:"config"`
+}
+
+// ConnectorAsConnect returns a connector in the format of the Kafka Connect REST API.
+func (c CreateUpdateConnectorPayload) ConnectorAsConnect() ConnectConnectorPayload {
+	return ConnectConnectorPayload{Name: c.Name, Config: c.Config}
+}
+
+// Properties returns the connector configuration as the properties of a Kafka Connect .properties file.
+func (config ConnectorConfig) Properties() map[string]string {
+	properties := make(map[string]string, len(config))
+	for key, value := range config {
+		if s, ok := value.(string); ok {
+			properties[key] = s
+			continue
+		}
+		properties[key] = fmt.Sprint(value)
+	}
+	return properties
+}
+
+// ConnectorConfigFromProperties returns the connector configuration of the properties of a Kafka Connect .properties file.
+func ConnectorConfigFromProperties(properties map[string]string) ConnectorConfig {
+	config := make(ConnectorConfig, len(properties))
+	for key, value := range properties {
+		config[key] = value
+	}
+	return config
+}
+
 // ApplyAndValidateName applies some rules to make sure that the connector's data are setup correctly.
 func (c *CreateUpdateConnectorPayload) ApplyAndValidateName() error {
 	if c.Config != nil {
@@ -2397,6 +2565,21 @@ type SchemaAsRequest struct {
 	AvroSchema string `json:"schema" yaml:"avroSchema"`
 }
 
+// SchemaSubjectsFileName is the name, without its extension, of the file which maps the subjects to their `.avsc` files.
+const SchemaSubjectsFileName = "subjects"
+
+// SchemaSubjectsFile maps the subjects of the schemas kept as `.avsc` files to their files.
+type SchemaSubjectsFile struct {
+	Subjects []SchemaSubjectFile `json:"subjects" yaml:"subjects"`
+}
+
+// SchemaSubjectFile is the `.avsc` file of a subject, "File" is relative to the directory of the `SchemaSubjectsFile`.
+type SchemaSubjectFile struct {
+	Subject string `json:"subject" yaml:"subject"`
+	Version int    `json:"version,omitempty" yaml:"version,omitempty"`
+	File    string `json:"file" yaml:"file"`
+}
+
 // GetSchemaAsRequest returns the schema as a request for import into another instance
 func (c *Client) GetSchemaAsRequest(schema Schema) SchemaAsRequest {
 	return SchemaAsRequest{
@@ -3546,6 +3729,15 @@ type BrokerConfig struct {
 	AdvertisedPort    int             `json:"advertised.port" yaml:"advertisedPort" header:"Advertised Port"`
 }
 
+// DynamicBrokerConfigs describes the dynamic configurations of the kafka brokers, keyed by their kafka names.
+type DynamicBrokerConfigs map[string]interface{}
+
+// BrokerConfigsPayload describes the dynamic configurations of the kafka cluster or, if "BrokerID" is set, of a broker in a landscape.
+type BrokerConfigsPayload struct {
+	BrokerID *int                 `json:"brokerId,omitempty" yaml:"brokerId,omitempty"`
+	Configs  DynamicBrokerConfigs `json:"configs" yaml:"configs"`
+}
+
 const (
 	brokersConfigsPath = "api/configs/brokers"
 	brokerConfigsPath  = brokersConfigsPath + "/%d"
@@ -3609,6 +3801,52 @@ func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerCo
 	return resp.Body.Close()
 }
 
+// GetDynamicClusterConfigsMap returns all the dynamic updated configurations for a kafka cluster.
+func (c *Client) GetDynamicClusterConfigsMap() (configs DynamicBrokerConfigs, err error) {
+	resp, err := c.Do(http.MethodGet, brokersConfigsPath, "", nil)
+	if err != nil {
+		return
+	}
+
+	err = c.ReadJSON(resp, &configs)
+	return
+}
+
+// GetDynamicBrokerConfigsMap returns all the dynamic updated configurations for a kafka broker.
+func (c *Client) GetDynamicBrokerConfigsMap(brokerID int) (configs DynamicBrokerConfigs, err error) {
+	resp, err := c.Do(http.MethodGet, fmt.Sprintf(brokerConfigsPath, brokerID), "", nil)
+	if err != nil {
+		return
+	}
+
+	err = c.ReadJSON(resp, &configs)
+	return
+}
+
+// UpdateDynamicClusterConfigsMap adds or updates any cluster configuration dynamically.
+func (c *Client) UpdateDynamicClusterConfigsMap(toAddOrUpdate DynamicBrokerConfigs) error {
+	return c.updateDynamicConfigs(brokersConfigsPath, toAddOrUpdate)
+}
+
+// UpdateDynamicBrokerConfigsMap adds or updates any broker configuration dynamically.
+func (c *Client) UpdateDynamicBrokerConfigsMap(brokerID int, toAddOrUpdate DynamicBrokerConfigs) error {
+	return c.updateDynamicConfigs(fmt.Sprintf(brokerConfigsPath, brokerID), toAddOrUpdate)
+}
+
+func (c *Client) updateDynamicConfigs(path string, toAddOrUpdate DynamicBrokerConfigs) error {
+	send, err := json.Marshal(toAddOrUpdate)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
 // DeleteDynamicClusterConfigs deletes cluster configuration(s) dynamically.
 // It reverts the configuration to its default value.
 func (c *Client) DeleteDynamicClusterConfigs(configKeysToBeReset ...string) error {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/lensesio/lenses-go/pkg"
 )
@@ -13,6 +14,7 @@ import (
 type SingleTopicOffset struct {
 	Type   string `json:"type" yaml:"type"`
 	Offset int    `json:"offset,omitempty" yaml:"offset"`
+	Target string `json:"target,omitempty" yaml:"target"`
 }
 
 // MultipleTopicOffsets represent the payload structure
@@ -56,3 +58,72 @@ func (c *Client) UpdateMultipleTopicsOffset(groupID, offsetType, target string,
 
 	return nil
 }
+
+// UpdateSingleTopicOffsetToTimestamp handles the API call to update
+// a single partition of a topic to the first offset at or after a timestamp.
+func (c *Client) UpdateSingleTopicOffsetToTimestamp(groupID, topic, partitionID string, timestamp time.Time) error {
+	path := fmt.Sprintf("%s/%s/offsets/topics/%s/partitions/%s", pkg.ConsumersGroupPath, groupID, topic, partitionID)
+	singleTopic := SingleTopicOffset{Type: "timestamp", Target: timestamp.UTC().Format(time.RFC3339)}
+	payload, err := json.Marshal(singleTopic)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	return err
+}
+
+// absoluteTopicOffset is the payload of `UpdateSingleTopicAbsoluteOffset`,
+// unlike `SingleTopicOffset` it keeps an offset of 0.
+type absoluteTopicOffset struct {
+	Type   string `json:"type"`
+	Offset int64  `json:"offset"`
+}
+
+// UpdateSingleTopicAbsoluteOffset handles the API call to update
+// a single partition of a topic to an offset, including the offset 0.
+func (c *Client) UpdateSingleTopicAbsoluteOffset(groupID, topic, partitionID string, offset int64) error {
+	path := fmt.Sprintf("%s/%s/offsets/topics/%s/partitions/%s", pkg.ConsumersGroupPath, groupID, topic, partitionID)
+	payload, err := json.Marshal(absoluteTopicOffset{Type: "absolute", Offset: offset})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	return err
+}
+
+// ConsumerPartitionOffset is the committed offset of a consumer group for a partition of a topic,
+// "Timestamp" is the time of the record at that offset in milliseconds, when it is known.
+type ConsumerPartitionOffset struct {
+	Topic     string `json:"topic" yaml:"topic"`
+	Partition int    `json:"partition" yaml:"partition"`
+	Offset    int64  `json:"offset" yaml:"offset"`
+	Timestamp int64  `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// ConsumerGroupOffsets describes the committed offsets of a consumer group, as returned by `GetConsumerGroupsOffsets`.
+type ConsumerGroupOffsets struct {
+	ID      string                    `json:"id" yaml:"id"`
+	State   ConsumerGroupState        `json:"state" yaml:"state"`
+	Offsets []ConsumerPartitionOffset `json:"offsets" yaml:"offsets"`
+}
+
+// ConsumerGroupOffsetsPayload describes the committed offsets of a consumer group in a landscape,
+// "Host" is the Lenses they were exported from.
+type ConsumerGroupOffsetsPayload struct {
+	Group   string                    `json:"group" yaml:"group"`
+	Host    string                    `json:"host,omitempty" yaml:"host,omitempty"`
+	Offsets []ConsumerPartitionOffset `json:"offsets" yaml:"offsets"`
+}
+
+// GetConsumerGroupsOffsets returns the consumer groups with their committed offsets.
+func (c *Client) GetConsumerGroupsOffsets() (groups []ConsumerGroupOffsets, err error) {
+	resp, err := c.Do(http.MethodGet, pkg.ConsumersGroupPath, "", nil)
+	if err != nil {
+		return
+	}
+
+	err = c.ReadJSON(resp, &groups)
+	return
+}
@@ -99,3 +99,60 @@ func (c *Client) UpdateDatasetTags(connection, name string, tags []string) (err
 	defer resp.Body.Close()
 	return nil
 }
+
+// Dataset describes a dataset of a connection, e.g. a kafka topic or an elasticsearch index, as returned by `GetDatasets`.
+type Dataset struct {
+	Name           string       `json:"name" yaml:"name" header:"Name"`
+	ConnectionName string       `json:"connectionName" yaml:"connectionName" header:"Connection"`
+	SourceType     string       `json:"sourceType,omitempty" yaml:"sourceType,omitempty" header:"Source Type,NULL"`
+	Description    string       `json:"description,omitempty" yaml:"description,omitempty" header:"Description,NULL"`
+	Tags           []DatasetTag `json:"tags" yaml:"tags"`
+}
+
+type datasetsResponse struct {
+	Datasets struct {
+		Values      []Dataset `json:"values"`
+		PagesAmount int       `json:"pagesAmount"`
+	} `json:"datasets"`
+}
+
+const datasetsPageSize = 500
+
+// GetDatasets returns the datasets of every connection, page by page.
+func (c *Client) GetDatasets() ([]Dataset, error) {
+	var datasets []Dataset
+
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("api/%s?page=%d&pageSize=%d", pkg.DatasetsAPIPath, page, datasetsPageSize)
+		resp, err := c.Do(http.MethodGet, path, "", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response datasetsResponse
+		if err = c.ReadJSON(resp, &response); err != nil {
+			return nil, err
+		}
+
+		datasets = append(datasets, response.Datasets.Values...)
+		if page >= response.Datasets.PagesAmount || len(response.Datasets.Values) == 0 {
+			return datasets, nil
+		}
+	}
+}
+
+// DatasetPayload describes the catalogue information of a dataset in a landscape,
+// a nil "Description" is left as it is.
+type DatasetPayload struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description *string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	KeyType     string   `json:"keyType,omitempty" yaml:"keyType,omitempty"`
+	ValueType   string   `json:"valueType,omitempty" yaml:"valueType,omitempty"`
+}
+
+// DatasetsPayload describes the catalogue information of the datasets of a connection in a landscape.
+type DatasetsPayload struct {
+	Connection string           `json:"connection" yaml:"connection"`
+	Datasets   []DatasetPayload `json:"datasets" yaml:"datasets"`
+}
@@ -0,0 +1,244 @@
+package broker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+const brokerIDFlagKey = "broker-id"
+
+//NewBrokersGroupCommand creates `brokers` command
+func NewBrokersGroupCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:              "brokers",
+		Short:            "Manage the kafka brokers",
+		Example:          "brokers configs get",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+	}
+
+	root.AddCommand(NewBrokerConfigsGroupCommand())
+	return root
+}
+
+//NewBrokerConfigsGroupCommand creates `brokers configs` command
+func NewBrokerConfigsGroupCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "configs",
+		Short: "Manage the dynamic configurations of the kafka cluster or of a broker",
+		Example: `brokers configs get
+brokers configs set log.cleaner.threads=2 compression.type=lz4
+brokers configs reset --broker-id=1 log.cleaner.threads`,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+	}
+
+	root.AddCommand(NewGetBrokerConfigsCommand())
+	root.AddCommand(NewSetBrokerConfigsCommand())
+	root.AddCommand(NewResetBrokerConfigsCommand())
+	return root
+}
+
+// configEntry is a row of the `brokers configs get` table.
+type configEntry struct {
+	Name  string      `json:"name" yaml:"name" header:"Name"`
+	Value interface{} `json:"value" yaml:"value" header:"Value,NULL"`
+}
+
+//NewGetBrokerConfigsCommand creates `brokers configs get` command
+func NewGetBrokerConfigsCommand() *cobra.Command {
+	var brokerID int
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Print the dynamic configurations of the kafka cluster, or of a broker with --broker-id",
+		Example: `brokers configs get
+brokers configs get --broker-id=1 --output json`,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configs, err := GetConfigs(config.Client, brokerIDFrom(cmd, brokerID))
+			if err != nil {
+				return fmt.Errorf("failed to retrieve the broker configs. [%v]", err)
+			}
+
+			if output := strings.ToUpper(bite.GetOutPutFlag(cmd)); output == "JSON" || output == "YAML" {
+				return bite.PrintObject(cmd, configs)
+			}
+
+			names := ConfigNames(configs)
+			entries := make([]configEntry, 0, len(names))
+			for _, name := range names {
+				entries = append(entries, configEntry{Name: name, Value: configs[name]})
+			}
+
+			return bite.PrintObject(cmd, entries)
+		},
+	}
+
+	cmd.Flags().IntVar(&brokerID, brokerIDFlagKey, 0, "Id of the broker, the configurations of the kafka cluster if not set")
+	bite.CanPrintJSON(cmd)
+	return cmd
+}
+
+//NewSetBrokerConfigsCommand creates `brokers configs set` command
+func NewSetBrokerConfigsCommand() *cobra.Command {
+	var brokerID int
+
+	cmd := &cobra.Command{
+		Use:     "set",
+		Aliases: []string{"update"},
+		Short:   "Add or update dynamic configurations of the kafka cluster, or of a broker with --broker-id",
+		Example: `brokers configs set log.cleaner.threads=2 compression.type=lz4
+brokers configs set --broker-id=1 log.cleaner.threads=4`,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		Args:             cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configs := make(api.DynamicBrokerConfigs, len(args))
+			for _, arg := range args {
+				kv := strings.SplitN(arg, "=", 2)
+				if len(kv) != 2 || kv[0] == "" {
+					return fmt.Errorf("invalid config [%s], expected key=value", arg)
+				}
+				configs[kv[0]] = kv[1]
+			}
+
+			id := brokerIDFrom(cmd, brokerID)
+			if err := UpdateConfigs(config.Client, id, configs); err != nil {
+				return fmt.Errorf("failed to update the configs of %s. [%v]", ScopeName(id), err)
+			}
+
+			return bite.PrintInfo(cmd, "Configs of %s updated", ScopeName(id))
+		},
+	}
+
+	cmd.Flags().IntVar(&brokerID, brokerIDFlagKey, 0, "Id of the broker, the configurations of the kafka cluster if not set")
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+//NewResetBrokerConfigsCommand creates `brokers configs reset` command
+func NewResetBrokerConfigsCommand() *cobra.Command {
+	var brokerID int
+
+	cmd := &cobra.Command{
+		Use:     "reset",
+		Aliases: []string{"delete"},
+		Short:   "Revert dynamic configurations of the kafka cluster, or of a broker with --broker-id, to their static or default values",
+		Example: `brokers configs reset log.cleaner.threads compression.type
+brokers configs reset --broker-id=1 log.cleaner.threads`,
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		Args:             cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := brokerIDFrom(cmd, brokerID)
+			if err := ResetConfigs(config.Client, id, args...); err != nil {
+				return fmt.Errorf("failed to reset the configs of %s. [%v]", ScopeName(id), err)
+			}
+
+			return bite.PrintInfo(cmd, "Configs of %s reset", ScopeName(id))
+		},
+	}
+
+	cmd.Flags().IntVar(&brokerID, brokerIDFlagKey, 0, "Id of the broker, the configurations of the kafka cluster if not set")
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+// brokerIDFrom returns the `--broker-id`, or nil for the kafka cluster if the flag is not set.
+func brokerIDFrom(cmd *cobra.Command, brokerID int) *int {
+	if !cmd.Flags().Changed(brokerIDFlagKey) {
+		return nil
+	}
+	return &brokerID
+}
+
+// ScopeName names the kafka cluster, if brokerID is nil, or a broker in the messages and the landscape files.
+func ScopeName(brokerID *int) string {
+	if brokerID == nil {
+		return "cluster"
+	}
+	return fmt.Sprintf("broker-%d", *brokerID)
+}
+
+// ConfigNames returns the sorted names of the configurations.
+func ConfigNames(configs api.DynamicBrokerConfigs) []string {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetConfigs returns the dynamic configurations of the kafka cluster, if brokerID is nil, or of a broker.
+func GetConfigs(client *api.Client, brokerID *int) (api.DynamicBrokerConfigs, error) {
+	if brokerID == nil {
+		return client.GetDynamicClusterConfigsMap()
+	}
+	return client.GetDynamicBrokerConfigsMap(*brokerID)
+}
+
+// UpdateConfigs adds or updates dynamic configurations of the kafka cluster, if brokerID is nil, or of a broker.
+func UpdateConfigs(client *api.Client, brokerID *int, configs api.DynamicBrokerConfigs) error {
+	if brokerID == nil {
+		return client.UpdateDynamicClusterConfigsMap(configs)
+	}
+	return client.UpdateDynamicBrokerConfigsMap(*brokerID, configs)
+}
+
+// ResetConfigs reverts dynamic configurations of the kafka cluster, if brokerID is nil, or of a broker.
+func ResetConfigs(client *api.Client, brokerID *int, names ...string) error {
+	if brokerID == nil {
+		return client.DeleteDynamicClusterConfigs(names...)
+	}
+	return client.DeleteDynamicBrokerConfigs(*brokerID, names...)
+}
+
+// KnownConfigs returns the configurations without the sensitive ones, whose values kafka does not return.
+func KnownConfigs(configs api.DynamicBrokerConfigs) api.DynamicBrokerConfigs {
+	known := make(api.DynamicBrokerConfigs, len(configs))
+	for name, value := range configs {
+		if value != nil {
+			known[name] = value
+		}
+	}
+	return known
+}
+
+// ReconcileConfigs updates the dynamic configurations of the cluster, or of a broker, to the desired ones
+// and resets the others, except the sensitive ones.
+func ReconcileConfigs(client *api.Client, brokerID *int, live, desired api.DynamicBrokerConfigs) error {
+	changed := make(api.DynamicBrokerConfigs)
+	for name, value := range desired {
+		if liveValue, ok := live[name]; !ok || liveValue == nil || fmt.Sprint(liveValue) != fmt.Sprint(value) {
+			changed[name] = value
+		}
+	}
+
+	var removed []string
+	for _, name := range ConfigNames(live) {
+		if _, ok := desired[name]; !ok && live[name] != nil {
+			removed = append(removed, name)
+		}
+	}
+
+	if len(changed) > 0 {
+		if err := UpdateConfigs(client, brokerID, changed); err != nil {
+			return err
+		}
+	}
+
+	if len(removed) > 0 {
+		return ResetConfigs(client, brokerID, removed...)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	test "github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const brokerConfigsResp = `{"log.cleaner.threads": "2", "compression.type": "lz4", "ssl.key.password": null}`
+
+func TestBrokerConfigsCommands(t *testing.T) {
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		if r.Method == http.MethodGet {
+			w.Write([]byte(brokerConfigsResp))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewGetBrokerConfigsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--broker-id", "1")
+	assert.Nil(t, err)
+	assert.JSONEq(t, brokerConfigsResp, output)
+
+	cmd = NewSetBrokerConfigsCommand()
+	_, err = test.ExecuteCommand(cmd, "log.cleaner.threads=4")
+	assert.Nil(t, err)
+
+	cmd = NewSetBrokerConfigsCommand()
+	_, err = test.ExecuteCommand(cmd, "log.cleaner.threads")
+	assert.EqualError(t, err, "invalid config [log.cleaner.threads], expected key=value")
+
+	cmd = NewResetBrokerConfigsCommand()
+	_, err = test.ExecuteCommand(cmd, "--broker-id", "2", "compression.type")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"GET /api/configs/brokers/1 ",
+		`PUT /api/configs/brokers {"log.cleaner.threads":"4"}`,
+		`DELETE /api/configs/brokers/2 ["compression.type"]`,
+	}, requests)
+}
+
+func TestReconcileConfigs(t *testing.T) {
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	live := api.DynamicBrokerConfigs{"log.cleaner.threads": "2", "compression.type": "lz4", "ssl.key.password": nil}
+	desired := api.DynamicBrokerConfigs{"log.cleaner.threads": 2, "log.retention.ms": "3600000"}
+	assert.Nil(t, ReconcileConfigs(client, nil, live, desired))
+
+	// the sensitive config is not reset, its live value is unknown.
+	assert.Equal(t, []string{
+		`PUT /api/configs/brokers {"log.retention.ms":"3600000"}`,
+		`DELETE /api/configs/brokers ["compression.type"]`,
+	}, requests)
+
+	requests = nil
+	assert.Nil(t, ReconcileConfigs(client, nil, live, api.DynamicBrokerConfigs{"log.cleaner.threads": "2", "compression.type": "lz4"}))
+	assert.Empty(t, requests)
+}
@@ -3,6 +3,8 @@ package connector
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -304,9 +307,10 @@ func NewConnectorCreateCommand() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:              "create",
-		Short:            "Create a new connector",
-		Example:          `connector create --cluster-name="cluster_name" --name="connector_name" --configs="{\"key\": \"value\"}" or connector create ./connector.yml`,
+		Use:   "create",
+		Short: "Create a new connector",
+		Example: `connector create --cluster-name="cluster_name" --name="connector_name" --configs="{\"key\": \"value\"}" or connector create ./connector.yml
+connector create --cluster-name="cluster_name" ./connector.properties`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -341,7 +345,7 @@ func NewConnectorCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&configRaw, "configs", "", `Connector config .e.g."{\"key\": \"value\"}"`) // --config conflicts with the global flag.
 	bite.CanBeSilent(cmd)
 
-	bite.ShouldTryLoadFile(cmd, &connector)
+	shouldTryLoadConnector(cmd, &connector)
 
 	return cmd
 }
@@ -413,7 +417,7 @@ func NewConnectorUpdateCommand() *cobra.Command {
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 
-	bite.ShouldTryLoadFile(cmd, &connector)
+	shouldTryLoadConnector(cmd, &connector)
 
 	return cmd
 }
@@ -732,3 +736,40 @@ func NewConnectorDeleteCommand() *cobra.Command {
 
 	return cmd
 }
+
+// shouldTryLoadConnector loads the connector of the file argument, a YAML or JSON file, e.g. a Kafka Connect {"name": .., "config": {..}} file,
+// or a Kafka Connect .properties file. The connector files without cluster take the one of the --cluster-name.
+func shouldTryLoadConnector(cmd *cobra.Command, connector *api.CreateUpdateConnectorPayload) {
+	isProperties := func(args []string) bool {
+		return len(args) > 0 && filepath.Ext(args[0]) == ".properties"
+	}
+
+	bite.ShouldTryLoadFile(cmd, connector).WithPathResolve(func(_ *cobra.Command, args []string) string {
+		if len(args) == 0 || isProperties(args) {
+			return ""
+		}
+		return args[0]
+	})
+
+	runE := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if isProperties(args) {
+			if err := bite.PrintInfo(c, "Loading from file '%s'", args[0]); err != nil {
+				return err
+			}
+
+			b, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			properties, err := utils.UnmarshalProperties(b)
+			if err != nil {
+				return fmt.Errorf("invalid connector file [%s]. [%v]", args[0], err)
+			}
+			connector.Config = api.ConnectorConfigFromProperties(properties)
+		}
+
+		return runE(c, args)
+	}
+}
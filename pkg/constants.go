@@ -21,11 +21,17 @@ const (
 	TopicsPath = "kafka/topics"
 	QuotasPath = "kafka/quotas"
 
+	BrokerConfigsPath   = "kafka/broker-configs"
+	ConsumerOffsetsPath = "kafka/consumer-offsets"
+
 	SchemasPath       = "schemas"
 	AlertSettingsPath = "alert-settings"
 	PoliciesPath      = "policies"
+	DatasetsPath      = "datasets"
 
 	ConnectionsFilePath        = "connections"
+	AlertChannelsFilePath      = "alert-channels"
+	AuditChannelsFilePath      = "audit-channels"
 	ConnectionsAPIPath         = "v1/connection/connections"
 	DatasetsAPIPath            = "v1/datasets"
 	ConnectionTemplatesAPIPath = "v1/connection/connection-templates"
@@ -0,0 +1,137 @@
+package dataset
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+)
+
+// KafkaConnection is the connection of the kafka topics, the only datasets with a `TopicMetadata`.
+const KafkaConnection = "kafka"
+
+// Catalogue returns the catalogue information of the datasets, their descriptions, tags and topic metadata,
+// by connection and sorted by name. The datasets without any are left out.
+func Catalogue(client *api.Client) (map[string][]api.DatasetPayload, error) {
+	datasets, err := client.GetDatasets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the datasets. [%v]", err)
+	}
+
+	metadata, err := client.GetTopicsMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the topics metadata. [%v]", err)
+	}
+
+	topics := make(map[string]api.TopicMetadata, len(metadata))
+	for _, meta := range metadata {
+		if meta.KeyType != "" || meta.ValueType != "" {
+			topics[meta.TopicName] = meta
+		}
+	}
+
+	catalogue := make(map[string][]api.DatasetPayload)
+	for _, d := range datasets {
+		payload := api.DatasetPayload{Name: d.Name}
+		if d.Description != "" {
+			description := d.Description
+			payload.Description = &description
+		}
+		for _, tag := range d.Tags {
+			payload.Tags = append(payload.Tags, tag.Name)
+		}
+		sort.Strings(payload.Tags)
+
+		if meta, ok := topics[d.Name]; ok && d.ConnectionName == KafkaConnection {
+			payload.KeyType, payload.ValueType = meta.KeyType, meta.ValueType
+			delete(topics, d.Name)
+		}
+
+		catalogue[d.ConnectionName] = append(catalogue[d.ConnectionName], payload)
+	}
+
+	// the metadata of the topics which are not datasets yet.
+	for _, meta := range topics {
+		catalogue[KafkaConnection] = append(catalogue[KafkaConnection], api.DatasetPayload{Name: meta.TopicName, KeyType: meta.KeyType, ValueType: meta.ValueType})
+	}
+
+	for connection, payloads := range catalogue {
+		described := payloads[:0]
+		for _, payload := range payloads {
+			if payload.Description != nil || len(payload.Tags) > 0 || payload.KeyType != "" || payload.ValueType != "" {
+				described = append(described, payload)
+			}
+		}
+
+		if len(described) == 0 {
+			delete(catalogue, connection)
+			continue
+		}
+
+		sort.Slice(described, func(i, j int) bool { return described[i].Name < described[j].Name })
+		catalogue[connection] = described
+	}
+
+	return catalogue, nil
+}
+
+// UpdateCatalogue updates the description, the tags and the topic metadata of a dataset which differ from the live ones,
+// "live" is nil if the dataset has no catalogue information yet.
+func UpdateCatalogue(client *api.Client, connection string, live *api.DatasetPayload, desired api.DatasetPayload) error {
+	if live == nil {
+		live = &api.DatasetPayload{Name: desired.Name}
+	}
+
+	if desired.Description != nil && *desired.Description != descriptionOf(*live) {
+		if err := client.UpdateDatasetDescription(connection, desired.Name, *desired.Description); err != nil {
+			return fmt.Errorf("failed to update the description of dataset [%s/%s]. [%v]", connection, desired.Name, err)
+		}
+	}
+
+	if !sameTags(desired.Tags, live.Tags) {
+		if err := client.UpdateDatasetTags(connection, desired.Name, desired.Tags); err != nil {
+			return fmt.Errorf("failed to update the tags of dataset [%s/%s]. [%v]", connection, desired.Name, err)
+		}
+	}
+
+	if desired.KeyType == live.KeyType && desired.ValueType == live.ValueType {
+		return nil
+	}
+
+	if connection != KafkaConnection {
+		return fmt.Errorf("dataset [%s/%s] has key and value types but only the topics of the [%s] connection have", connection, desired.Name, KafkaConnection)
+	}
+
+	metadata := api.TopicMetadata{TopicName: desired.Name, KeyType: desired.KeyType, ValueType: desired.ValueType}
+	if err := client.CreateOrUpdateTopicMetadata(metadata); err != nil {
+		return fmt.Errorf("failed to update the metadata of topic [%s]. [%v]", desired.Name, err)
+	}
+
+	return nil
+}
+
+func descriptionOf(payload api.DatasetPayload) string {
+	if payload.Description == nil {
+		return ""
+	}
+
+	return *payload.Description
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
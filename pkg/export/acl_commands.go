@@ -9,7 +9,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,45 +21,59 @@ func NewExportAclsCommand() *cobra.Command {
 		Example:          `export acls`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
 
-			if err := writeACLs(cmd, config.Client); err != nil {
+			if err := writeACLs(cmd, config.Client, opts); err != nil {
 				golog.Errorf("Error writing ACLS. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeACLs(cmd *cobra.Command, client *api.Client) error {
+func writeACLs(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("acls.%s", strings.ToLower(output))
 
-	acls, err := client.GetACLs()
+	all, err := client.GetACLs()
 
 	if err != nil {
 		return err
 	}
 
+	opts.warnUnfiltered("acls", false, false)
+
+	// the acls are filtered by the name of their resource, e.g. a topic.
+	var acls []api.ACL
+	for _, acl := range all {
+		if opts.exported(filterable{name: acl.ResourceName}) {
+			acls = append(acls, acl)
+		}
+	}
+
 	if len(acls) == 0 {
 		fmt.Fprintf(cmd.OutOrStdout(), "no available ACLs for export\n")
 		return nil
 	}
 
-	if err := utils.WriteFile(landscapeDir, pkg.AclsPath, fileName, output, acls); err != nil {
+	if err := opts.writeLandscapeFile(pkg.AclsPath, fileName, output, acls); err != nil {
 		return err
 	}
 
-	exportPath := fmt.Sprintf("%s/%s/%s", landscapeDir, pkg.AclsPath, fileName)
+	exportPath := fmt.Sprintf("%s/%s/%s", opts.dir, pkg.AclsPath, fileName)
 	fmt.Fprintf(cmd.OutOrStdout(), "ACLs have been successfully exported at %s\n", exportPath)
 	return nil
 }
@@ -18,17 +18,22 @@ func NewExportAlertChannelsCommand() *cobra.Command {
 		Example:          `export alert-channels --resource-name=my-alert`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeChannels(cmd, pkg.AlertChannelsPath, "alert", alertChannelName); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeChannels(cmd, opts, pkg.AlertChannelsPath, "alert", alertChannelName); err != nil {
 				return fmt.Errorf("failed to export alert channels from server: [%v]", err)
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().StringVar(&alertChannelName, "resource-name", "", "The name of the alert channel to export")
+	canMaskSecrets(cmd)
 	//cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract alert channel dependencies, e.g. connections")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
@@ -10,7 +10,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg/alert"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,43 +22,48 @@ func NewExportAlertsCommand() *cobra.Command {
 		Example:          `export alert-settings --resource-name=my-alert`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeAlertSetting(cmd, config.Client); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeAlertSetting(cmd, config.Client, opts); err != nil {
 				return fmt.Errorf("error writing alert settings. [%s]", err.Error())
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeAlertSetting(cmd *cobra.Command, client *api.Client) error {
+func writeAlertSetting(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+	opts.warnUnfiltered("alert settings", false, true)
 
-	producerSettings, err := getProducerAlertSettings(client)
+	producerSettings, err := getProducerAlertSettings(client, opts)
 	if err != nil {
 		return err
 	}
-	consumerSettings, err := getConsumerAlertSettings(client)
+	consumerSettings, err := getConsumerAlertSettings(client, opts)
 	if err != nil {
 		return err
 	}
-	writeProducerAlertSettings(cmd, producerSettings)
-	writeConsumerAlertSettings(cmd, consumerSettings)
+	writeProducerAlertSettings(cmd, opts, producerSettings)
+	writeConsumerAlertSettings(cmd, opts, consumerSettings)
 
 	return nil
 }
 
-func writeProducerAlertSettings(cmd *cobra.Command, settings api.ProducerAlertSettings) error {
+func writeProducerAlertSettings(cmd *cobra.Command, opts *exportOptions, settings api.ProducerAlertSettings) error {
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("alert-setting-producer.%s", strings.ToLower(output))
 
-	err := utils.WriteFile(landscapeDir, pkg.AlertSettingsPath, fileName, output, settings)
+	err := opts.writeLandscapeFile(pkg.AlertSettingsPath, fileName, output, settings)
 	if err != nil {
 		return fmt.Errorf("error writing to %s. [%v]", fileName, err)
 	}
@@ -68,11 +72,11 @@ func writeProducerAlertSettings(cmd *cobra.Command, settings api.ProducerAlertSe
 	return nil
 }
 
-func writeConsumerAlertSettings(cmd *cobra.Command, settings api.ConsumerAlertSettings) error {
+func writeConsumerAlertSettings(cmd *cobra.Command, opts *exportOptions, settings api.ConsumerAlertSettings) error {
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("alert-setting-consumer.%s", strings.ToLower(output))
 
-	err := utils.WriteFile(landscapeDir, pkg.AlertSettingsPath, fileName, output, settings)
+	err := opts.writeLandscapeFile(pkg.AlertSettingsPath, fileName, output, settings)
 	if err != nil {
 		return fmt.Errorf("error writing to %s. [%v]", fileName, err)
 	}
@@ -81,11 +85,11 @@ func writeConsumerAlertSettings(cmd *cobra.Command, settings api.ConsumerAlertSe
 	return nil
 }
 
-func writeAlertSettingsAsRequest(cmd *cobra.Command, settings alert.SettingConditionPayloads) error {
+func writeAlertSettingsAsRequest(cmd *cobra.Command, opts *exportOptions, settings alert.SettingConditionPayloads) error {
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("alert-setting.%s", strings.ToLower(output))
 
-	err := utils.WriteFile(landscapeDir, pkg.AlertSettingsPath, fileName, output, settings)
+	err := opts.writeLandscapeFile(pkg.AlertSettingsPath, fileName, output, settings)
 	if err != nil {
 		return fmt.Errorf("error writing to %s. [%v]", fileName, err)
 	}
@@ -134,7 +138,13 @@ func getAlertSettings(cmd *cobra.Command, client *api.Client, topics []string) (
 	return alert.SettingConditionPayloads{AlertID: 2000, Conditions: conditions}, nil
 }
 
-func getConsumerAlertSettings(client *api.Client) (api.ConsumerAlertSettings, error) {
+// alertConditionExported applies the export filters to an alert condition by the name of its topic.
+func alertConditionExported(opts *exportOptions, condition api.AlertConditionDetails) bool {
+	topic, _ := condition.ConditionDsl["topic"].(string)
+	return opts.exported(filterable{name: topic, owner: condition.CreatedBy, owned: true})
+}
+
+func getConsumerAlertSettings(client *api.Client, opts *exportOptions) (api.ConsumerAlertSettings, error) {
 	var consumerAlertSettings api.ConsumerAlertSettings
 
 	settings, err := client.GetAlertSetting(2000)
@@ -147,6 +157,10 @@ func getConsumerAlertSettings(client *api.Client) (api.ConsumerAlertSettings, er
 
 	// iterate over the consumer condition details
 	for _, condDetail := range settings.ConditionDetails {
+		if !alertConditionExported(opts, condDetail) {
+			continue
+		}
+
 		jsonStringCondition, _ := json.Marshal(condDetail.ConditionDsl)
 
 		consumerAlertConditionDetail := api.ConsumerAlertConditionRequestv1{}
@@ -163,7 +177,7 @@ func getConsumerAlertSettings(client *api.Client) (api.ConsumerAlertSettings, er
 	return consumerAlertSettings, nil
 }
 
-func getProducerAlertSettings(client *api.Client) (api.ProducerAlertSettings, error) {
+func getProducerAlertSettings(client *api.Client, opts *exportOptions) (api.ProducerAlertSettings, error) {
 	var producerAlertSettings api.ProducerAlertSettings
 
 	settings, err := client.GetAlertSetting(5000)
@@ -176,6 +190,10 @@ func getProducerAlertSettings(client *api.Client) (api.ProducerAlertSettings, er
 
 	// iterate over the data produced condition details
 	for _, condDetail := range settings.ConditionDetails {
+		if !alertConditionExported(opts, condDetail) {
+			continue
+		}
+
 		jsonStringCondition, _ := json.Marshal(condDetail.ConditionDsl)
 
 		producerAlertConditionDetail := api.AlertConditionRequestv1{}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// exportResource is a resource type of a landscape and the way to export all of its resources.
+type exportResource struct {
+	Name  string
+	Path  string
+	write func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error
+}
+
+// exportResources returns every resource type supported by the export commands.
+func exportResources() []exportResource {
+	return []exportResource{
+		{Name: "connections", Path: pkg.ConnectionsFilePath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeConnections(cmd, opts, "")
+		}},
+		{Name: "alert-channels", Path: pkg.AlertChannelsFilePath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeChannels(cmd, opts, pkg.AlertChannelsPath, "alert", "")
+		}},
+		{Name: "audit-channels", Path: pkg.AuditChannelsFilePath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeChannels(cmd, opts, pkg.AuditChannelsPath, "audit", "")
+		}},
+		{Name: "topics", Path: pkg.TopicsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeTopics(cmd, client, opts, "")
+		}},
+		{Name: "schemas", Path: pkg.SchemasPath, write: writeSchemas},
+		{Name: "acls", Path: pkg.AclsPath, write: writeACLs},
+		{Name: "quotas", Path: pkg.QuotasPath, write: writeQuotas},
+		{Name: "broker-configs", Path: pkg.BrokerConfigsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeBrokerConfigs(cmd, client, opts, nil)
+		}},
+		{Name: "groups", Path: pkg.GroupsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeGroups(cmd, opts, "")
+		}},
+		{Name: "serviceaccounts", Path: pkg.ServiceAccountsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeServiceAccounts(cmd, opts, "")
+		}},
+		{Name: "policies", Path: pkg.PoliciesPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writePolicies(cmd, client, opts, "", "")
+		}},
+		{Name: "processors", Path: pkg.SQLPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeProcessors(cmd, client, opts, "", "", "", "")
+		}},
+		{Name: "connectors", Path: pkg.ConnectorsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeConnectors(cmd, client, opts, "", "")
+		}},
+		{Name: "alert-settings", Path: pkg.AlertSettingsPath, write: writeAlertSetting},
+		{Name: "datasets", Path: pkg.DatasetsPath, write: func(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+			return writeDatasets(cmd, client, opts, "")
+		}},
+	}
+}
+
+//NewExportAllCommand creates `export all` command
+func NewExportAllCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "export every resource of a landscape and a manifest describing its origin",
+		Example: `export all --dir my-landscape
+export all --dir my-landscape --output json
+export all --dir my-landscape --mask-secrets
+export all --dir my-landscape --sql-files --avsc`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
+			client := config.Client
+
+			if err := opts.setExecutionMode(client); err != nil {
+				return fmt.Errorf("failed to retrieve the execution mode. [%v]", err)
+			}
+			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			opts.checkAvscFiles()
+
+			resources := exportResources()
+			if err := exportAll(cmd, client, opts, resources); err != nil {
+				return err
+			}
+
+			opts.manifest = resources
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
+	canMaskSecrets(cmd)
+	canSQLFiles(cmd)
+	canAvscFiles(cmd)
+	bite.CanBeSilent(cmd)
+	bite.CanPrintJSON(cmd)
+	return cmd
+}
+
+// exportAll exports the resources concurrently, the export of a resource type does not stop on the failure of another.
+func exportAll(cmd *cobra.Command, client *api.Client, opts *exportOptions, resources []exportResource) error {
+	cmd.SetOut(utils.NewSyncWriter(cmd.OutOrStdout()))
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []string
+	)
+
+	for _, resource := range resources {
+		wg.Add(1)
+		go func(resource exportResource) {
+			defer wg.Done()
+
+			if err := resource.write(cmd, client, opts); err != nil {
+				golog.Errorf("Error writing %s. [%s]", resource.Name, err.Error())
+				mu.Lock()
+				failed = append(failed, resource.Name)
+				mu.Unlock()
+			}
+		}(resource)
+	}
+
+	wg.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("failed to export [%s]", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// writeManifest writes the landscape manifest of the exported resources, see `export all`.
+func writeManifest(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
+	manifest, err := newManifest(client, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.WriteManifest(opts.dir, manifest); err != nil {
+		return fmt.Errorf("failed to write the landscape manifest. [%v]", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "landscape with [%d] files has been successfully exported at %s\n", len(manifest.Files), opts.dir)
+	return nil
+}
+
+func newManifest(client *api.Client, opts *exportOptions) (utils.Manifest, error) {
+	cfg, err := client.GetConfig()
+	if err != nil {
+		return utils.Manifest{}, fmt.Errorf("failed to retrieve the Lenses configuration. [%v]", err)
+	}
+
+	paths := make([]string, 0, len(opts.manifest))
+	for _, resource := range opts.manifest {
+		paths = append(paths, resource.Path)
+	}
+
+	if opts.singleFile != "" {
+		if rel, err := filepath.Rel(opts.dir, opts.singleFilePath()); err == nil && !strings.HasPrefix(rel, "..") {
+			paths = []string{rel}
+		}
+	}
+
+	files, err := utils.ChecksumFiles(opts.dir, paths...)
+	if err != nil {
+		return utils.Manifest{}, fmt.Errorf("failed to compute the checksums of the landscape files. [%v]", err)
+	}
+
+	return utils.Manifest{
+		Host:          client.Config.Host,
+		LensesVersion: cfg.Version,
+		ExecutionMode: opts.mode,
+		ExportedAt:    time.Now().UTC(),
+		CLIVersion:    api.BuildVersion,
+		Files:         files,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const exportAllConfigResp = `{
+  "lenses.version": "4.1.0",
+  "lenses.sql.execution.mode": "IN_PROC",
+  "lenses.kafka.connect.clusters": []
+}`
+
+const exportAllTopicsResp = `[
+  {"topicName": "payments", "partitions": 3, "replication": 1, "configs": []},
+  {"topicName": "_kafka_lenses_processors", "partitions": 1, "replication": 1, "configs": []}
+]`
+
+func TestExportAllCommand(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-all", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/config":
+			w.Write([]byte(exportAllConfigResp))
+		case r.URL.Path == "/api/topics":
+			w.Write([]byte(exportAllTopicsResp))
+		case r.URL.Path == "/api/configs/brokers":
+			w.Write([]byte(`{"log.cleaner.threads": "2", "listener.name.internal.ssl.key.password": null}`))
+		case r.URL.Path == "/api/v1/datasets":
+			w.Write([]byte(`{"datasets": {"values": [{"name": "payments", "connectionName": "kafka", "description": "Card payments"}], "pagesAmount": 1}}`))
+		case r.URL.Path == "/api/v1/streams":
+			w.Write([]byte(`{"streams": []}`))
+		case r.URL.Path == "/api/v1/alert/settings":
+			w.Write([]byte(`{"categories": {}}`))
+		case strings.HasSuffix(r.URL.Path, "/channels"):
+			w.Write([]byte(`{"values": []}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewExportAllCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Nil(t, err)
+
+	manifest, found, err := utils.ReadManifest(dir)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, client.Config.Host, manifest.Host)
+	assert.Equal(t, "4.1.0", manifest.LensesVersion)
+	assert.Equal(t, api.ExecutionModeInProcess, manifest.ExecutionMode)
+	assert.False(t, manifest.ExportedAt.IsZero())
+
+	topicFile := pkg.TopicsPath + "/topic-payments.yaml"
+	assert.Contains(t, manifest.Files, topicFile)
+	_, err = os.Stat(filepath.Join(dir, filepath.FromSlash(topicFile)))
+	assert.Nil(t, err)
+
+	brokerConfigs, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(pkg.BrokerConfigsPath), "broker-configs-cluster.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "configs:\n  log.cleaner.threads: \"2\"\n", string(brokerConfigs))
+	assert.Contains(t, manifest.Files, pkg.DatasetsPath+"/datasets-kafka.yaml")
+
+	changes, err := manifest.VerifyFiles(dir, pkg.TopicsPath)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(topicFile)), []byte("name: changed\n"), 0666))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, pkg.TopicsPath, "topic-orders.yaml"), []byte("name: orders\n"), 0666))
+	changes, err = manifest.VerifyFiles(dir, pkg.TopicsPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"[" + pkg.TopicsPath + "/topic-orders.yaml] was added",
+		"[" + topicFile + "] was modified",
+	}, changes)
+}
+
+func TestSnapshotIgnoresExportFlags(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-snapshot", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(exportAllTopicsResp))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	// the flags of the export commands do not apply to the snapshots.
+	includePatterns, singleFile = []string{"orders"}, "landscape.yaml"
+	defer func() { includePatterns, singleFile = nil, "" }()
+
+	assert.Nil(t, Snapshot(client, dir, []string{"topics"}))
+	_, err := os.Stat(filepath.Join(dir, pkg.TopicsPath, "topic-payments.yaml"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dir, "landscape.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
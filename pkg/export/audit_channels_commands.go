@@ -18,17 +18,22 @@ func NewExportAuditChannelsCommand() *cobra.Command {
 		Example:          `export audit-channels --resource-name=my-audit`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeChannels(cmd, pkg.AuditChannelsPath, "audit", auditChannelName); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeChannels(cmd, opts, pkg.AuditChannelsPath, "audit", auditChannelName); err != nil {
 				return fmt.Errorf("failed to export audit channels from server: [%v]", err)
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().StringVar(&auditChannelName, "resource-name", "", "The name of the audit channel to export")
+	canMaskSecrets(cmd)
 	//cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract audit channel dependencies, e.g. connections")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
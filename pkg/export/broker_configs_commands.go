@@ -0,0 +1,92 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/broker"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+//NewExportBrokerConfigsCommand creates `export broker-configs` command
+func NewExportBrokerConfigsCommand() *cobra.Command {
+	var brokerIDs []int
+
+	cmd := &cobra.Command{
+		Use:   "broker-configs",
+		Short: "export the dynamic configurations of the kafka cluster and brokers",
+		Long: `Export the dynamic configurations of the kafka cluster, and of the brokers given with --broker-id.
+The sensitive configurations are not exported, kafka does not return their values.`,
+		Example: `export broker-configs --dir my-dir
+export broker-configs --dir my-dir --broker-id 1,2,3`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
+			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+
+			if err := writeBrokerConfigs(cmd, config.Client, opts, brokerIDs); err != nil {
+				golog.Errorf("Error writing broker configs. [%s]", err.Error())
+				return err
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	cmd.Flags().IntSliceVar(&brokerIDs, "broker-id", nil, "Ids of the brokers to export the configurations of, in addition to the ones of the kafka cluster")
+	canFilter(cmd)
+	canMaskSecrets(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+// writeBrokerConfigs writes the configurations of the kafka cluster to "broker-configs-cluster" and the ones of every broker
+// to "broker-configs-broker-<id>", the scopes are filtered by these names.
+func writeBrokerConfigs(cmd *cobra.Command, client *api.Client, opts *exportOptions, brokerIDs []int) error {
+	opts.warnUnfiltered("broker configs", false, false)
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+
+	scopes := []*int{nil}
+	for i := range brokerIDs {
+		scopes = append(scopes, &brokerIDs[i])
+	}
+
+	for _, brokerID := range scopes {
+		name := broker.ScopeName(brokerID)
+		if !opts.exported(filterable{name: name}) {
+			continue
+		}
+
+		configs, err := broker.GetConfigs(client, brokerID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the configs of %s. [%v]", name, err)
+		}
+
+		known := broker.KnownConfigs(configs)
+		if skipped := len(configs) - len(known); skipped > 0 {
+			golog.Warnf("[%d] sensitive configs of %s are not exported, kafka does not return their values", skipped, name)
+		}
+
+		masked, err := opts.maskConfig("broker-config", name, api.ConnectorConfig(known))
+		if err != nil {
+			return err
+		}
+
+		payload := api.BrokerConfigsPayload{BrokerID: brokerID, Configs: api.DynamicBrokerConfigs(masked)}
+		fileName := fmt.Sprintf("broker-configs-%s.%s", name, strings.ToLower(output))
+		if err := opts.writeLandscapeFile(pkg.BrokerConfigsPath, fileName, output, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
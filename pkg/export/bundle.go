@@ -0,0 +1,68 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bundleFlagKey  = "bundle"
+	signKeyFlagKey = "sign-key"
+)
+
+// canBundle registers the bundle flags of the export commands, they are applied by bundleLandscape once a command exported its landscape.
+func canBundle(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(bundleFlagKey, "", "Also pack the exported landscape into this gzipped tar file, with a manifest of the SHA-256 checksums of its files, e.g. out.tar.gz")
+	cmd.PersistentFlags().String(signKeyFlagKey, "", "Sign the --bundle manifest with this PEM encoded PKCS #8 ed25519, RSA or ECDSA private key, e.g. created with 'openssl genpkey -algorithm ed25519 -out key.pem'")
+}
+
+// bundlePaths returns the files and resource directories of the landscape directory which are packed in a bundle.
+func bundlePaths(opts *exportOptions) []string {
+	if opts.singleFile != "" {
+		if rel, err := filepath.Rel(opts.dir, opts.singleFilePath()); err == nil && !strings.HasPrefix(rel, "..") {
+			return []string{rel, utils.ManifestFileName}
+		}
+	}
+
+	paths := []string{utils.ManifestFileName}
+	for _, kind := range utils.DocumentKinds {
+		paths = append(paths, kind.Path)
+	}
+	return paths
+}
+
+// bundleLandscape packs the landscape directory into the `--bundle` file, signed with the `--sign-key` if set.
+func bundleLandscape(cmd *cobra.Command, opts *exportOptions) error {
+	bundle, _ := cmd.Flags().GetString(bundleFlagKey)
+	signKey, _ := cmd.Flags().GetString(signKeyFlagKey)
+
+	if bundle == "" {
+		if signKey != "" {
+			return fmt.Errorf("--%s requires --%s", signKeyFlagKey, bundleFlagKey)
+		}
+		return nil
+	}
+
+	if opts.singleFile != "" && filepath.IsAbs(opts.singleFile) {
+		if rel, err := filepath.Rel(opts.dir, opts.singleFile); err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("the single file [%s] must be inside the landscape directory [%s] to be bundled", opts.singleFile, opts.dir)
+		}
+	}
+
+	manifest, err := utils.WriteBundle(bundle, opts.dir, bundlePaths(opts), signKey)
+	if err != nil {
+		return fmt.Errorf("failed to write the landscape bundle [%s]. [%v]", bundle, err)
+	}
+
+	if signKey != "" {
+		bite.PrintInfo(cmd, "Landscape bundle with [%d] files signed and written to [%s]", len(manifest.Files), bundle)
+	} else {
+		bite.PrintInfo(cmd, "Landscape bundle with [%d] files written to [%s]", len(manifest.Files), bundle)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportBundle(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-bundle", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"topicName": "orders", "partitions": 1, "replication": 1, "config": []}]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	landscape := filepath.Join(dir, "landscape")
+	bundle := filepath.Join(dir, "out", "landscape.tar.gz")
+
+	cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "topics", "--dir", landscape, "--bundle", bundle)
+	assert.Nil(t, err)
+
+	extracted := filepath.Join(dir, "extracted")
+	manifest, signed, err := utils.ExtractBundle(bundle, extracted, "")
+	assert.Nil(t, err)
+	assert.False(t, signed)
+	assert.Equal(t, []string{pkg.TopicsPath + "/topic-orders.yaml"}, fileNames(manifest.Files))
+
+	exported, err := ioutil.ReadFile(filepath.Join(landscape, pkg.TopicsPath, "topic-orders.yaml"))
+	assert.Nil(t, err)
+	b, err := ioutil.ReadFile(filepath.Join(extracted, pkg.TopicsPath, "topic-orders.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, string(exported), string(b))
+
+	// a signing key without a bundle is a mistake.
+	cmd = test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err = test.ExecuteCommand(cmd, "topics", "--dir", landscape, "--sign-key", filepath.Join(dir, "key.pem"))
+	assert.EqualError(t, err, "--sign-key requires --bundle")
+}
+
+func fileNames(files map[string]string) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	return names
+}
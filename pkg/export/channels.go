@@ -8,11 +8,10 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-func writeChannels(cmd *cobra.Command, channelsPath, channelType, channelName string) error {
+func writeChannels(cmd *cobra.Command, opts *exportOptions, channelsPath, channelType, channelName string) error {
 	channels, err := config.Client.GetChannels(channelsPath, 1, 99999, "name", "asc", "", "")
 	if err != nil {
 		return fmt.Errorf("failed to retrieve channels from server: [%v]", err)
@@ -29,15 +28,24 @@ func writeChannels(cmd *cobra.Command, channelsPath, channelType, channelName st
 
 				// return nil
 
-				writeChannelToFile(cmd, channelType, channel.Name, channel)
+				if channel.Properties, err = opts.maskProperties(channelType+"-channel", channel.Name, channel.Properties); err != nil {
+					return err
+				}
+				writeChannelToFile(cmd, opts, channelType, channel.Name, channel)
 			}
 		}
 
 		return fmt.Errorf("%s channel with name [%s] was not found", channelType, channelName)
 	}
 
+	opts.warnUnfiltered(channelType+" channels", false, true)
+
 	var channelsForExport []api.ChannelPayload
 	for _, chann := range channels.Values {
+		if !opts.exported(filterable{name: chann.Name, owner: chann.CreatedBy, owned: true}) {
+			continue
+		}
+
 		var channForExport api.ChannelPayload
 		channΑsJSON, _ := json.Marshal(chann)
 		json.Unmarshal(channΑsJSON, &channForExport)
@@ -45,17 +53,20 @@ func writeChannels(cmd *cobra.Command, channelsPath, channelType, channelName st
 	}
 
 	for _, channelForExport := range channelsForExport {
-		writeChannelToFile(cmd, channelType, channelForExport.Name, channelForExport)
+		if channelForExport.Properties, err = opts.maskProperties(channelType+"-channel", channelForExport.Name, channelForExport.Properties); err != nil {
+			return err
+		}
+		writeChannelToFile(cmd, opts, channelType, channelForExport.Name, channelForExport)
 	}
 
 	return nil
 }
 
-func writeChannelToFile(cmd *cobra.Command, channelType, channelName string, channel interface{}) error {
+func writeChannelToFile(cmd *cobra.Command, opts *exportOptions, channelType, channelName string, channel interface{}) error {
 	fileName := fmt.Sprintf("%s-channel-%s.%s", channelType, strings.ToLower(channelName), strings.ToLower(bite.GetOutPutFlag(cmd)))
 	subDir := channelType + "-channels"
 
-	utils.WriteFile(landscapeDir, subDir, fileName, strings.ToUpper(bite.GetOutPutFlag(cmd)), channel)
+	opts.writeLandscapeFile(subDir, fileName, strings.ToUpper(bite.GetOutPutFlag(cmd)), channel)
 
 	fmt.Fprintf(cmd.OutOrStdout(), "exported %s channel [%s] to [%s]\n", channelType, channelName, fileName)
 
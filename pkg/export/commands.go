@@ -2,18 +2,14 @@ package export
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
-	"github.com/lensesio/lenses-go/pkg/utils"
 
 	"github.com/kataras/golog"
 	"github.com/spf13/cobra"
-	"gopkg.in/src-d/go-git.v4"
-	"gopkg.in/src-d/go-git.v4/plumbing"
 )
 
 const (
@@ -21,28 +17,9 @@ const (
 	sqlConnectorClass = "com.landoop.connect.SQL"
 )
 
-var mode api.ExecutionMode
 var dependents bool
 var landscapeDir string
-var systemTopicExclusions = []string{
-	"connect-configs",
-	"connect-offsets",
-	"connect-status",
-	"connect-statuses",
-	"_schemas",
-	"__consumer_offsets",
-	"_kafka_lenses_",
-	"lsql_",
-	"__transaction_state",
-	"__topology",
-	"__topology__metrics",
-	"_connect-configs",
-	"_connect-status",
-	"_connect-offsets",
-	"_lenses_",
-}
 
-var topicExclusions string
 var prefix string
 
 //NewExportGroupCommand creates the `export` command
@@ -51,6 +28,8 @@ func NewExportGroupCommand() *cobra.Command {
 		Use:   "export",
 		Short: "export a landscape",
 		Example: `	
+export all --dir my-dir
+export all --dir my-dir --include='payments-*' --exclude='/-(dlq|retry)$/' --owner=alice
 export acls --dir my-dir
 export alert-settings --dir my-dir
 export alert-channels
@@ -63,12 +42,23 @@ export policies --dir my-dir --resource-name my-policy
 export connections --dir my-dir
 export connections --dir my-dir --connection-id 1
 export groups --dir groups
-export serviceaccounts --dir serviceaccounts`,
+export serviceaccounts --dir serviceaccounts
+export broker-configs --dir my-dir --broker-id 1,2,3
+export datasets --dir my-dir --connection kafka
+export consumer-offsets --dir my-dir --group-pattern='payments-*'
+export all --dir landscape --git-commit --message "Nightly export" --git-push
+export all --dir landscape --single-file landscape.yaml
+export all --dir landscape --bundle landscape.tar.gz --sign-key key.pem
+export topics --dir landscape --git-branch 'export/{{.Context}}/{{.Date}}' --git-commit --git-push`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 	}
 
 	cmd.MarkPersistentFlagRequired("dir")
+	canGit(cmd)
+	canSingleFile(cmd)
+	canBundle(cmd)
+	cmd.AddCommand(NewExportAllCommand())
 	cmd.AddCommand(NewExportAclsCommand())
 	cmd.AddCommand(NewExportAlertsCommand())
 	cmd.AddCommand(NewExportConnectorsCommand())
@@ -82,21 +72,13 @@ export serviceaccounts --dir serviceaccounts`,
 	cmd.AddCommand(NewExportServiceAccountsCommand())
 	cmd.AddCommand(NewExportAlertChannelsCommand())
 	cmd.AddCommand(NewExportAuditChannelsCommand())
+	cmd.AddCommand(NewExportBrokerConfigsCommand())
+	cmd.AddCommand(NewExportDatasetsCommand())
+	cmd.AddCommand(NewExportConsumerOffsetsCommand())
 
 	return cmd
 }
 
-func setExecutionMode(client *api.Client) error {
-	execMode, err := getExecutionMode(client)
-
-	if err != nil {
-		return err
-	}
-
-	mode = execMode
-	return nil
-}
-
 func getExecutionMode(client *api.Client) (api.ExecutionMode, error) {
 	mode, err := client.GetExecutionMode()
 	if err != nil {
@@ -106,10 +88,10 @@ func getExecutionMode(client *api.Client) (api.ExecutionMode, error) {
 	return mode, nil
 }
 
-func getAttachedTopics(client *api.Client, id string) ([]api.CreateTopicPayload, error) {
+func getAttachedTopics(client *api.Client, opts *exportOptions, id string) ([]api.CreateTopicPayload, error) {
 	var topics []api.CreateTopicPayload
 
-	if dependents {
+	if opts.dependents {
 		extractedTopics, err := client.GetTopicExtract(id)
 
 		if err != nil {
@@ -128,7 +110,7 @@ func getAttachedTopics(client *api.Client, id string) ([]api.CreateTopicPayload,
 						return topics, err
 					}
 
-					overrides := getTopicConfigOverrides(topic.Configs)
+					overrides := topic.ConfigOverrides()
 					topics = append(topics, topic.GetTopicAsRequest(overrides))
 				}
 			}
@@ -138,42 +120,10 @@ func getAttachedTopics(client *api.Client, id string) ([]api.CreateTopicPayload,
 	return topics, nil
 }
 
-func createBranch(cmd *cobra.Command, branchName string) error {
-
-	dir, err := os.Getwd()
-
-	if err != nil {
-		golog.Fatal(err)
-		return err
-	}
-
-	r, err := git.PlainOpen(dir)
-
-	if err != nil {
-		return err
-	}
-
-	w, err := r.Worktree()
-
-	if err != nil {
-		return err
-	}
-
-	branch := fmt.Sprintf("refs/heads/%s", branchName)
-	b := plumbing.ReferenceName(branch)
-	if err = w.Checkout(&git.CheckoutOptions{Create: true, Force: false, Branch: b}); err != nil {
-		return err
-	}
-
-	bite.PrintInfo(cmd, "Branch [%s] created", branchName)
-
-	return nil
-}
-
-func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
+func handleDependents(cmd *cobra.Command, client *api.Client, opts *exportOptions, id string) error {
 
 	//get topics
-	topics, err := getAttachedTopics(client, id)
+	topics, err := getAttachedTopics(client, opts, id)
 
 	if err != nil {
 		return err
@@ -185,12 +135,12 @@ func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
 		topicNames = append(topicNames, t.TopicName)
 	}
 
-	if len(topics) == 0 && dependents {
+	if len(topics) == 0 && opts.dependents {
 		golog.Error(fmt.Sprintf("No topics found in the topology for processor [%s]", id))
 	}
 
 	// write topics
-	writeTopicsAsRequest(cmd, topics)
+	writeTopicsAsRequest(cmd, opts, topics)
 
 	// get alert settings
 	settings, err := getAlertSettings(cmd, client, topicNames)
@@ -199,7 +149,7 @@ func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
 		return err
 	}
 
-	writeAlertSettingsAsRequest(cmd, settings)
+	writeAlertSettingsAsRequest(cmd, opts, settings)
 
 	//get acls
 	acls, err := client.GetACLs()
@@ -221,7 +171,7 @@ func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
 	}
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("acls-%s.%s", "all", strings.ToLower(output))
-	return utils.WriteFile(landscapeDir, pkg.AclsPath, fileName, output, topicAcls)
+	return opts.writeLandscapeFile(pkg.AclsPath, fileName, output, topicAcls)
 }
 
 func checkFileFlags(cmd *cobra.Command) {
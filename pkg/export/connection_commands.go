@@ -7,7 +7,6 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -18,28 +17,34 @@ func NewExportConnectionsCommand() *cobra.Command {
 		Use:   "connections",
 		Short: "export connections",
 		Example: `export connections
-export connections --name connection-name`,
+export connections --name connection-name
+export connections --mask-secrets`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeConnections(cmd, connectionName); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeConnections(cmd, opts, connectionName); err != nil {
 				return fmt.Errorf("error while exporting connections. [%s]", err.Error())
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().StringVar(&connectionName, "name", "", "The name of the connection to extract")
+	canMaskSecrets(cmd)
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
 // writeConnections retrieves and writes one or all connections to a file
-func writeConnections(cmd *cobra.Command, connectionName string) error {
-	fmt.Fprintf(cmd.OutOrStdout(), "writing connections to base directory [%s]\n", landscapeDir)
+func writeConnections(cmd *cobra.Command, opts *exportOptions, connectionName string) error {
+	fmt.Fprintf(cmd.OutOrStdout(), "writing connections to base directory [%s]\n", opts.dir)
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
@@ -49,8 +54,12 @@ func writeConnections(cmd *cobra.Command, connectionName string) error {
 			return err
 		}
 
+		if connection.Configuration, err = opts.maskConnectionConfig(connection.Name, connection.Configuration); err != nil {
+			return err
+		}
+
 		fileName := fmt.Sprintf("connection-%s-%s.%s", strings.ToLower(strings.ReplaceAll(connection.Name, " ", "_")), connection.Name, strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.ConnectionsFilePath, fileName, output, connection)
+		return opts.writeLandscapeFile(pkg.ConnectionsFilePath, fileName, output, connection)
 	}
 
 	connections, err := config.Client.GetConnections()
@@ -64,8 +73,16 @@ func writeConnections(cmd *cobra.Command, connectionName string) error {
 			return err
 		}
 
+		if !opts.exported(filterable{name: connectionComplete.Name, tags: connectionComplete.Tags, owner: connectionComplete.CreatedBy, tagged: true, owned: true}) {
+			continue
+		}
+
+		if connectionComplete.Configuration, err = opts.maskConnectionConfig(connection.Name, connectionComplete.Configuration); err != nil {
+			return err
+		}
+
 		fileName := fmt.Sprintf("connection-%s-%s.%s", strings.ToLower(strings.ReplaceAll(connection.Name, " ", "_")), connection.Name, strings.ToLower(output))
-		err = utils.WriteFile(landscapeDir, pkg.ConnectionsFilePath, fileName, output, connectionComplete)
+		err = opts.writeLandscapeFile(pkg.ConnectionsFilePath, fileName, output, connectionComplete)
 		if err != nil {
 			return fmt.Errorf("could not export connection to file %s", fileName)
 		}
@@ -1,6 +1,7 @@
 package export
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -13,33 +14,54 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	connectorFormatLenses      = "lenses"
+	connectorFormatConnectJSON = "connect-json"
+	connectorFormatProperties  = "properties"
+)
+
+// connectorFormat is the format of the connector files, see `--format`.
+var connectorFormat = connectorFormatLenses
+
 //NewExportConnectorsCommand creates `export connectors` command
 func NewExportConnectorsCommand() *cobra.Command {
 	var name, cluster string
 
 	cmd := &cobra.Command{
-		Use:              "connectors",
-		Short:            "export connectors",
-		Example:          `export connectors --resource-name my-connector --cluster-name cluster1`,
+		Use:   "connectors",
+		Short: "export connectors",
+		Example: `export connectors --resource-name my-connector --cluster-name cluster1
+export connectors --mask-secrets --secret-placeholder='${vault:secret/data/connectors/{{.Name}}#{{.Key}}}'
+export connectors --cluster-name cluster1 --format properties`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			client := config.Client
-			setExecutionMode(client)
+			opts.setExecutionMode(client)
 			checkFileFlags(cmd)
-			if err := writeConnectors(cmd, client, cluster, name); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := opts.checkConnectorFormat(); err != nil {
+				return err
+			}
+			if err := writeConnectors(cmd, client, opts, cluster, name); err != nil {
 				golog.Errorf("Error writing connectors. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The resource name to export")
 	cmd.Flags().StringVar(&cluster, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Connector with the prefix in the name only")
+	cmd.Flags().StringVar(&connectorFormat, "format", connectorFormatLenses, "Format of the connector files: lenses, the landscape files of the --output, "+
+		"or the Kafka Connect formats connect-json, {\"name\": .., \"config\": {..}}, and properties, which have no cluster")
+	canMaskSecrets(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
@@ -48,13 +70,15 @@ func NewExportConnectorsCommand() *cobra.Command {
 // writeConnectors writes the connectors to files as yaml
 // If a clusterName is provided the connectors are filtered by clusterName
 // If a name is provided the connectors are filtered by connector name
-func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string, name string) error {
+func writeConnectors(cmd *cobra.Command, client *api.Client, opts *exportOptions, clusterName string, name string) error {
 	clusters, err := client.GetConnectClusters()
 
 	if err != nil {
 		return err
 	}
 
+	opts.warnUnfiltered("connectors", false, false)
+
 	for _, cluster := range clusters {
 
 		connectorNames, err := client.GetConnectors(cluster.Name)
@@ -73,7 +97,11 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 				continue
 			}
 
-			if prefix != "" && !strings.HasPrefix(connectorName, prefix) {
+			if opts.prefix != "" && !strings.HasPrefix(connectorName, opts.prefix) {
+				continue
+			}
+
+			if !opts.exported(filterable{name: connectorName}) {
 				continue
 			}
 
@@ -87,23 +115,66 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 			}
 
 			request := connector.ConnectorAsRequest()
+			if request.Config, err = opts.maskConfig("connector", fmt.Sprintf("%s-%s", cluster.Name, connectorName), request.Config); err != nil {
+				return err
+			}
 
 			output := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			fileName := fmt.Sprintf("connector-%s-%s.%s", strings.ToLower(cluster.Name), strings.ToLower(connectorName), strings.ToLower(output))
-
 			if output == "TABLE" {
 				output = "YAML"
 			}
 
-			golog.Debugf("Exporting connector [%s.%s] to [%s%s]", cluster.Name, connectorName, landscapeDir, fileName)
-			if err := utils.WriteFile(landscapeDir, pkg.ConnectorsPath, fileName, output, request); err != nil {
+			if err := opts.writeConnector(cluster.Name, connectorName, output, request); err != nil {
 				return err
 			}
 
-			if dependents {
-				handleDependents(cmd, client, fmt.Sprintf("%s:%s", connector.ClusterName, connector.Name))
+			if opts.dependents {
+				handleDependents(cmd, client, opts, fmt.Sprintf("%s:%s", connector.ClusterName, connector.Name))
 			}
 		}
 	}
 	return nil
 }
+
+// checkConnectorFormat checks the `--format` of the connector files, the single file holds the connectors as documents.
+func (opts *exportOptions) checkConnectorFormat() error {
+	switch opts.connectorFormat {
+	case connectorFormatLenses:
+	case connectorFormatConnectJSON, connectorFormatProperties:
+		if opts.singleFile != "" {
+			golog.Warnf("Connectors are written to the single file as documents, --format %s is ignored", opts.connectorFormat)
+			opts.connectorFormat = connectorFormatLenses
+		}
+	default:
+		return fmt.Errorf("unknown connector format [%s], expected %s, %s or %s", opts.connectorFormat, connectorFormatLenses, connectorFormatConnectJSON, connectorFormatProperties)
+	}
+
+	return nil
+}
+
+// writeConnector writes the file of a connector in the `--format`.
+func (opts *exportOptions) writeConnector(clusterName, connectorName, output string, request api.CreateUpdateConnectorPayload) error {
+	stem := fmt.Sprintf("connector-%s-%s", strings.ToLower(clusterName), strings.ToLower(connectorName))
+
+	var (
+		fileName string
+		data     []byte
+	)
+	switch opts.connectorFormat {
+	case connectorFormatConnectJSON:
+		b, err := json.MarshalIndent(request.ConnectorAsConnect(), "", "  ")
+		if err != nil {
+			return err
+		}
+		fileName, data = stem+".json", append(b, '\n')
+	case connectorFormatProperties:
+		fileName, data = stem+".properties", utils.MarshalProperties(request.Config.Properties())
+	default:
+		fileName = fmt.Sprintf("%s.%s", stem, strings.ToLower(output))
+		golog.Debugf("Exporting connector [%s.%s] to [%s%s]", clusterName, connectorName, opts.dir, fileName)
+		return opts.writeLandscapeFile(pkg.ConnectorsPath, fileName, output, request)
+	}
+
+	golog.Debugf("Exporting connector [%s.%s] to [%s%s]", clusterName, connectorName, opts.dir, fileName)
+	return utils.WriteBytesFile(opts.dir, pkg.ConnectorsPath, fileName, data)
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportConnectorsFormat(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-connectors", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "IN_PROC", "lenses.kafka.connect.clusters": [{"name": "dev"}]}`))
+		case "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["file-sink"]`))
+		default:
+			w.Write([]byte(`{"name": "file-sink", "type": "sink", "tasks": [], "config": {"name": "file-sink",
+  "connector.class": "org.apache.kafka.connect.file.FileStreamSinkConnector", "topics": "orders", "file": "/tmp/orders = all.txt", "tasks.max": "1"}}`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+	defer func() { connectorFormat = connectorFormatLenses }()
+
+	cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "connectors", "--dir", dir, "--format", "properties")
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, pkg.ConnectorsPath, "connector-dev-file-sink.properties"))
+	assert.Nil(t, err)
+	assert.Equal(t, `connector.class=org.apache.kafka.connect.file.FileStreamSinkConnector
+file=/tmp/orders = all.txt
+name=file-sink
+tasks.max=1
+topics=orders
+`, string(b))
+
+	cmd = test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err = test.ExecuteCommand(cmd, "connectors", "--dir", dir, "--format", "connect-json")
+	assert.Nil(t, err)
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, pkg.ConnectorsPath, "connector-dev-file-sink.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "name": "file-sink",
+  "config": {
+    "connector.class": "org.apache.kafka.connect.file.FileStreamSinkConnector",
+    "file": "/tmp/orders = all.txt",
+    "name": "file-sink",
+    "tasks.max": "1",
+    "topics": "orders"
+  }
+}
+`, string(b))
+
+	cmd = test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err = test.ExecuteCommand(cmd, "connectors", "--dir", dir, "--format", "xml")
+	assert.EqualError(t, err, "unknown connector format [xml], expected lenses, connect-json or properties")
+}
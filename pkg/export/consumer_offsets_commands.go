@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+//NewExportConsumerOffsetsCommand creates `export consumer-offsets` command
+func NewExportConsumerOffsetsCommand() *cobra.Command {
+	var groupPattern string
+
+	cmd := &cobra.Command{
+		Use:   "consumer-offsets",
+		Short: "export the committed offsets of consumer groups",
+		Long: `Export the committed offsets of consumer groups, a file per group with the offset of every partition
+and the timestamp of the record at that offset when it is known.
+The offsets are state rather than configuration, they are not part of "export all".`,
+		Example: `export consumer-offsets --dir my-dir
+export consumer-offsets --dir my-dir --group-pattern='payments-*'`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
+			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if groupPattern != "" {
+				if _, err := matchPattern(groupPattern, ""); err != nil {
+					return err
+				}
+			}
+
+			if err := writeConsumerOffsets(cmd, config.Client, opts, groupPattern); err != nil {
+				golog.Errorf("Error writing consumer offsets. [%s]", err.Error())
+				return err
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
+	cmd.Flags().StringVar(&groupPattern, "group-pattern", "", "Export only the consumer groups matching this glob pattern, or regular expression between slashes")
+	bite.CanBeSilent(cmd)
+	bite.CanPrintJSON(cmd)
+	return cmd
+}
+
+func writeConsumerOffsets(cmd *cobra.Command, client *api.Client, opts *exportOptions, groupPattern string) error {
+	groups, err := client.GetConsumerGroupsOffsets()
+	if err != nil {
+		return err
+	}
+
+	opts.warnUnfiltered("consumer offsets", false, false)
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+
+	for _, group := range groups {
+		if groupPattern != "" {
+			if matched, _ := matchPattern(groupPattern, group.ID); !matched {
+				continue
+			}
+		}
+
+		if !opts.exported(filterable{name: group.ID}) || len(group.Offsets) == 0 {
+			continue
+		}
+
+		offsets := append([]api.ConsumerPartitionOffset{}, group.Offsets...)
+		sort.Slice(offsets, func(i, j int) bool {
+			if offsets[i].Topic != offsets[j].Topic {
+				return offsets[i].Topic < offsets[j].Topic
+			}
+			return offsets[i].Partition < offsets[j].Partition
+		})
+
+		payload := api.ConsumerGroupOffsetsPayload{Group: group.ID, Host: client.Config.Host, Offsets: offsets}
+		fileName := fmt.Sprintf("consumer-offsets-%s.%s", strings.ToLower(group.ID), strings.ToLower(output))
+		if err := opts.writeLandscapeFile(pkg.ConsumerOffsetsPath, fileName, output, payload); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "exported the offsets of consumer group [%s] to [%s]\n", group.ID, fileName)
+	}
+
+	return nil
+}
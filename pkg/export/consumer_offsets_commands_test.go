@@ -0,0 +1,53 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportConsumerOffsets(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-consumer-offsets", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+  {"id": "payments", "state": "Empty", "offsets": [
+    {"topic": "orders", "partition": 1, "offset": 20, "timestamp": 1577836860000},
+    {"topic": "orders", "partition": 0, "offset": 10, "timestamp": 1577836800000}
+  ]},
+  {"id": "payments-idle", "state": "Empty", "offsets": []},
+  {"id": "clicks", "state": "Stable", "offsets": [{"topic": "clicks", "partition": 0, "offset": 5}]}
+]`))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewExportConsumerOffsetsCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir, "--group-pattern", "payments*")
+	assert.Nil(t, err)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, pkg.ConsumerOffsetsPath))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, pkg.ConsumerOffsetsPath, "consumer-offsets-payments.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `group: payments
+host: `+client.Config.Host+`
+offsets:
+- topic: orders
+  partition: 0
+  offset: 10
+  timestamp: 1577836800000
+- topic: orders
+  partition: 1
+  offset: 20
+  timestamp: 1577836860000
+`, string(b))
+}
@@ -0,0 +1,89 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/dataset"
+	"github.com/spf13/cobra"
+)
+
+//NewExportDatasetsCommand creates `export datasets` command
+func NewExportDatasetsCommand() *cobra.Command {
+	var connection string
+
+	cmd := &cobra.Command{
+		Use:   "datasets",
+		Short: "export the descriptions, tags and topic metadata of the datasets",
+		Long: `Export the catalogue information of the datasets, a file per connection with the description and the tags of its datasets,
+and the key and value types of the kafka topics. The datasets without any are not exported.`,
+		Example: `export datasets --dir my-dir
+export datasets --dir my-dir --connection kafka --tag pii`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
+			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+
+			if err := writeDatasets(cmd, config.Client, opts, connection); err != nil {
+				golog.Errorf("Error writing datasets. [%s]", err.Error())
+				return err
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	cmd.Flags().StringVar(&connection, "connection", "", "Export only the datasets of this connection")
+	canFilter(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+func writeDatasets(cmd *cobra.Command, client *api.Client, opts *exportOptions, connection string) error {
+	catalogue, err := dataset.Catalogue(client)
+	if err != nil {
+		return err
+	}
+
+	opts.warnUnfiltered("datasets", true, false)
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+
+	connections := make([]string, 0, len(catalogue))
+	for name := range catalogue {
+		if connection == "" || name == connection {
+			connections = append(connections, name)
+		}
+	}
+	sort.Strings(connections)
+
+	for _, name := range connections {
+		var datasets []api.DatasetPayload
+		for _, d := range catalogue[name] {
+			if opts.exported(filterable{name: d.Name, tags: d.Tags, tagged: true}) {
+				datasets = append(datasets, d)
+			}
+		}
+
+		if len(datasets) == 0 {
+			continue
+		}
+
+		payload := api.DatasetsPayload{Connection: name, Datasets: datasets}
+		fileName := fmt.Sprintf("datasets-%s.%s", strings.ToLower(name), strings.ToLower(output))
+		if err := opts.writeLandscapeFile(pkg.DatasetsPath, fileName, output, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var singleFile string
+
+// exportDocuments collects the exported resources when `--single-file` is set, the resources of "export all" are exported concurrently.
+type exportDocuments struct {
+	mu   sync.Mutex
+	docs []utils.Document
+}
+
+// canSingleFile registers the `--single-file` flag of the export commands.
+func canSingleFile(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&singleFile, "single-file", "",
+		"Write the resources to this multi-document YAML file, relative to --dir, as documents with kind and apiVersion headers instead of a file per resource")
+}
+
+// writeLandscapeFile writes the file of a resource to the directory layout of the landscape,
+// or collects it as a document if `--single-file` is set.
+func (opts *exportOptions) writeLandscapeFile(basePath, fileName, format string, resource interface{}) error {
+	if opts.singleFile == "" {
+		return utils.WriteFile(opts.dir, basePath, fileName, format, resource)
+	}
+
+	document, err := utils.NewDocument(basePath, fileName, resource)
+	if err != nil {
+		return err
+	}
+
+	opts.documents.mu.Lock()
+	opts.documents.docs = append(opts.documents.docs, document)
+	opts.documents.mu.Unlock()
+	return nil
+}
+
+// singleFilePath returns the path of the `--single-file`.
+func (opts *exportOptions) singleFilePath() string {
+	if filepath.IsAbs(opts.singleFile) {
+		return opts.singleFile
+	}
+	return filepath.Join(opts.dir, opts.singleFile)
+}
+
+// writeDocuments writes the collected documents to the `--single-file`, if any.
+func (opts *exportOptions) writeDocuments() error {
+	opts.documents.mu.Lock()
+	docs := opts.documents.docs
+	opts.documents.docs = nil
+	opts.documents.mu.Unlock()
+
+	if opts.singleFile == "" || len(docs) == 0 {
+		return nil
+	}
+
+	b, err := utils.MarshalDocuments(docs)
+	if err != nil {
+		return err
+	}
+
+	path := opts.singleFilePath()
+	if err = utils.CreateDirectory(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(path, b, 0666); err != nil {
+		return fmt.Errorf("failed to write the landscape file [%s]. [%v]", path, err)
+	}
+
+	return nil
+}
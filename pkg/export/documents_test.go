@@ -0,0 +1,60 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSingleFile(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-single-file", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+  {"topicName": "payments", "partitions": 3, "replication": 1, "config": []},
+  {"topicName": "orders", "partitions": 1, "replication": 1, "config": []}
+]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+	defer func() { singleFile = "" }()
+
+	cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "topics", "--dir", dir, "--single-file", "landscape.yaml")
+	assert.Nil(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, pkg.TopicsPath))
+	assert.True(t, os.IsNotExist(err))
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "landscape.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: landscape.lenses.io/v1
+kind: Topic
+metadata:
+  name: topic-orders
+spec:
+  name: orders
+  replication: 1
+  partitions: 1
+  description: ""
+  configs: {}
+---
+apiVersion: landscape.lenses.io/v1
+kind: Topic
+metadata:
+  name: topic-payments
+spec:
+  name: payments
+  replication: 1
+  partitions: 3
+  description: ""
+  configs: {}
+`, string(b))
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/spf13/cobra"
+)
+
+var includePatterns, excludePatterns, tagFilters, ownerFilters []string
+
+// canFilter registers the `--include`, `--exclude`, `--tag` and `--owner` flags to an export command.
+func canFilter(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&includePatterns, "include", nil, "Export only the resources whose name matches one of these glob patterns, or regular expressions between slashes, e.g. --include='orders-*,/^payments-[0-9]+$/'")
+	cmd.Flags().StringSliceVar(&excludePatterns, "exclude", nil, "Do not export the resources whose name matches one of these glob patterns, or regular expressions between slashes, it takes precedence over --include")
+	cmd.Flags().StringSliceVar(&tagFilters, "tag", nil, "Export only the resources with one of these tags, applies to the connections and the topics")
+	cmd.Flags().StringSliceVar(&ownerFilters, "owner", nil, "Export only the resources created by one of these users, applies to the resources which record who created them")
+}
+
+// checkFilters validates the patterns of the `--include` and `--exclude` flags.
+func (opts *exportOptions) checkFilters() error {
+	for _, pattern := range append(append([]string{}, opts.include...), opts.exclude...) {
+		if _, err := matchPattern(pattern, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// matchPattern matches a name against a glob pattern, or against a regular expression if the pattern is between slashes.
+func matchPattern(pattern, name string) (bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expr, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression [%s]. [%v]", pattern, err)
+		}
+		return expr.MatchString(name), nil
+	}
+
+	matched, err := path.Match(pattern, name)
+	if err != nil {
+		return false, fmt.Errorf("invalid glob pattern [%s]. [%v]", pattern, err)
+	}
+	return matched, nil
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := matchPattern(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterable is what the export filters know of a resource.
+type filterable struct {
+	name   string
+	tags   []string
+	owner  string
+	tagged bool
+	owned  bool
+}
+
+// exported reports whether a resource passes the export filters.
+func (opts *exportOptions) exported(f filterable) bool {
+	if len(opts.include) > 0 && !matchAny(opts.include, f.name) {
+		return false
+	}
+
+	if matchAny(opts.exclude, f.name) {
+		return false
+	}
+
+	if f.tagged && len(opts.tags) > 0 && !containsAny(opts.tags, f.tags) {
+		return false
+	}
+
+	if f.owned && len(opts.owners) > 0 && !containsAny(opts.owners, []string{f.owner}) {
+		return false
+	}
+
+	return true
+}
+
+func containsAny(wanted, values []string) bool {
+	for _, w := range wanted {
+		for _, v := range values {
+			if strings.EqualFold(w, v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// warnUnfiltered warns that the `--tag` or `--owner` filters are set but do not apply to a resource type.
+func (opts *exportOptions) warnUnfiltered(kind string, tagged, owned bool) {
+	if len(opts.tags) > 0 && !tagged {
+		golog.Warnf("The --tag filter does not apply to %s, they have no tags", kind)
+	}
+
+	if len(opts.owners) > 0 && !owned {
+		golog.Warnf("The --owner filter does not apply to %s, they do not record who created them", kind)
+	}
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterableExported(t *testing.T) {
+	opts := &exportOptions{include: []string{"orders-*", "/^payments-[0-9]+$/"}, exclude: []string{"*-dlq"}}
+	assert.Nil(t, opts.checkFilters())
+
+	assert.True(t, opts.exported(filterable{name: "orders-eu"}))
+	assert.True(t, opts.exported(filterable{name: "payments-42"}))
+	assert.False(t, opts.exported(filterable{name: "payments-eu"}))
+	assert.False(t, opts.exported(filterable{name: "orders-dlq"}))
+
+	opts.tags, opts.owners = []string{"pii"}, []string{"alice"}
+	assert.True(t, opts.exported(filterable{name: "orders-eu", tags: []string{"PII"}, tagged: true}))
+	assert.False(t, opts.exported(filterable{name: "orders-eu", tags: []string{"public"}, tagged: true}))
+	assert.False(t, opts.exported(filterable{name: "orders-eu", owner: "bob", owned: true}))
+	// the filters do not apply to the resources without tags or owner.
+	assert.True(t, opts.exported(filterable{name: "orders-eu"}))
+
+	opts.exclude = []string{"/[/"}
+	assert.EqualError(t, opts.checkFilters(), "invalid regular expression [/[/]. [error parsing regexp: missing closing ]: `[`]")
+}
+
+func TestExportTopicsWithFilters(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-filters", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+  {"topicName": "orders", "partitions": 1, "replication": 1, "tags": [{"name": "sales"}]},
+  {"topicName": "orders-dlq", "partitions": 1, "replication": 1, "tags": [{"name": "sales"}]},
+  {"topicName": "invoices", "partitions": 1, "replication": 1, "tags": [{"name": "finance"}]},
+  {"topicName": "clicks", "partitions": 1, "replication": 1}
+]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+	defer func() { includePatterns, excludePatterns, tagFilters, ownerFilters = nil, nil, nil, nil }()
+
+	cmd := test.WithOutput(NewExportTopicsCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir, "--exclude", "*-dlq", "--tag", "sales,finance", "--owner", "alice")
+	assert.Nil(t, err)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, pkg.TopicsPath))
+	assert.Nil(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"topic-invoices.yaml", "topic-orders.yaml"}, names)
+}
@@ -0,0 +1,340 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lensesio/bite"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+	"gopkg.in/src-d/go-git.v4"
+	gitconfig "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	gitformat "gopkg.in/src-d/go-git.v4/plumbing/format/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+)
+
+const (
+	gitCommitFlagKey  = "git-commit"
+	gitMessageFlagKey = "message"
+	gitPushFlagKey    = "git-push"
+	gitBranchFlagKey  = "git-branch"
+	gitRemoteFlagKey  = "git-remote"
+
+	// envGitUsername and envGitPassword are the credentials of a push to an HTTP(S) remote,
+	// SSH remotes authenticate with the SSH agent.
+	envGitUsername = "GIT_USERNAME"
+	envGitPassword = "GIT_PASSWORD"
+	// envGitAuthorName and envGitAuthorEmail take precedence over the `user` of the git configuration.
+	envGitAuthorName  = "GIT_AUTHOR_NAME"
+	envGitAuthorEmail = "GIT_AUTHOR_EMAIL"
+)
+
+// canGit registers the git flags of the export commands, they are applied by commitLandscape once a command exported its landscape.
+func canGit(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(gitCommitFlagKey, false, "Commit the changed landscape files to the git repository of the landscape directory")
+	cmd.PersistentFlags().String(gitMessageFlagKey, "", "Subject of the --git-commit, the changed resources are listed in its body")
+	cmd.PersistentFlags().Bool(gitPushFlagKey, false, "Push the current branch to the --git-remote")
+	cmd.PersistentFlags().String(gitBranchFlagKey, "", "Name template of a branch to switch to before committing, with the fields .Context, .Host, .Resource, .Date and .Timestamp, e.g. 'export/{{.Context}}/{{.Date}}'")
+	cmd.PersistentFlags().String(gitRemoteFlagKey, "origin", "Remote to push to, HTTP(S) remotes read the credentials from the "+envGitUsername+" and "+envGitPassword+" environment variables")
+}
+
+// branchNameFields are the fields of the `--git-branch` template.
+type branchNameFields struct {
+	Context   string
+	Host      string
+	Resource  string
+	Date      string
+	Timestamp string
+}
+
+func branchName(cmd *cobra.Command, nameTemplate string, now time.Time) (string, error) {
+	tmpl, err := template.New("git-branch").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch name template [%s]. [%v]", nameTemplate, err)
+	}
+
+	fields := branchNameFields{
+		Resource:  cmd.Name(),
+		Date:      now.Format("20060102"),
+		Timestamp: now.Format("20060102-150405"),
+	}
+
+	if config.Manager != nil && config.Manager.Config != nil {
+		fields.Context = config.Manager.Config.CurrentContext
+	}
+
+	if config.Client != nil {
+		fields.Host = strings.TrimPrefix(strings.TrimPrefix(config.Client.Config.Host, "https://"), "http://")
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, fields); err != nil {
+		return "", fmt.Errorf("invalid branch name template [%s]. [%v]", nameTemplate, err)
+	}
+
+	name := strings.TrimSpace(b.String())
+	if name == "" || strings.ContainsAny(name, " ~^:?*[\\") {
+		return "", fmt.Errorf("invalid branch name [%s]", name)
+	}
+
+	return name, nil
+}
+
+// commitLandscape switches to the `--git-branch`, commits the changed files of the landscape directory with `--git-commit`
+// and pushes the current branch with `--git-push`.
+func commitLandscape(cmd *cobra.Command, dir string) error {
+	commit, _ := cmd.Flags().GetBool(gitCommitFlagKey)
+	push, _ := cmd.Flags().GetBool(gitPushFlagKey)
+	branchTemplate, _ := cmd.Flags().GetString(gitBranchFlagKey)
+
+	if !commit && !push && branchTemplate == "" {
+		return nil
+	}
+
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return fmt.Errorf("landscape directory [%s] is not in a git repository. [%v]", dir, err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	if branchTemplate != "" {
+		name, err := branchName(cmd, branchTemplate, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if err = checkoutBranch(repo, w, name); err != nil {
+			return fmt.Errorf("failed to switch to branch [%s]. [%v]", name, err)
+		}
+		bite.PrintInfo(cmd, "Switched to branch [%s]", name)
+	}
+
+	if commit {
+		message, _ := cmd.Flags().GetString(gitMessageFlagKey)
+		hash, committed, err := commitChanges(repo, w, dir, message)
+		if err != nil {
+			return fmt.Errorf("failed to commit the landscape. [%v]", err)
+		}
+
+		if !committed {
+			bite.PrintInfo(cmd, "No landscape changes to commit")
+		} else {
+			bite.PrintInfo(cmd, "Landscape changes committed [%s]", hash.String()[:7])
+		}
+	}
+
+	if push {
+		remote, _ := cmd.Flags().GetString(gitRemoteFlagKey)
+		if err = pushBranch(repo, remote); err != nil {
+			return fmt.Errorf("failed to push to remote [%s]. [%v]", remote, err)
+		}
+		bite.PrintInfo(cmd, "Pushed to remote [%s]", remote)
+	}
+
+	return nil
+}
+
+// checkoutBranch switches to a branch, created from HEAD if it does not exist, keeping the exported files.
+func checkoutBranch(repo *git.Repository, w *git.Worktree, name string) error {
+	ref := plumbing.NewBranchReferenceName(name)
+
+	// a repository without commits has no HEAD to branch from, the first commit goes to the branch HEAD points to.
+	if _, err := repo.Head(); err == plumbing.ErrReferenceNotFound {
+		return repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, ref))
+	}
+
+	_, err := repo.Reference(ref, false)
+	create := err == plumbing.ErrReferenceNotFound
+	if err != nil && !create {
+		return err
+	}
+
+	return w.Checkout(&git.CheckoutOptions{Branch: ref, Create: create, Keep: true})
+}
+
+// commitChanges stages the added, modified and deleted files under the landscape directory, and only them,
+// and commits them. It returns false if there is nothing to commit.
+func commitChanges(repo *git.Repository, w *git.Worktree, dir, message string) (plumbing.Hash, bool, error) {
+	root := w.Filesystem.Root()
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+
+	// the repository root may be reached through a symbolic link, e.g. a temporary directory.
+	if resolved, err := filepath.EvalSymlinks(absDir); err == nil {
+		absDir = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	relDir, err := filepath.Rel(root, absDir)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+	relDir = filepath.ToSlash(relDir)
+
+	status, err := w.Status()
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+
+	changes := make(map[string]string)
+	for file, fileStatus := range status {
+		if relDir != "." && !strings.HasPrefix(file, relDir+"/") {
+			continue
+		}
+
+		switch {
+		case fileStatus.Worktree == git.Deleted:
+			if _, err = w.Remove(file); err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			changes[file] = "deleted"
+		case fileStatus.Worktree == git.Untracked || fileStatus.Staging == git.Added:
+			if _, err = w.Add(file); err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			changes[file] = "added"
+		case fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified:
+			if _, err = w.Add(file); err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			changes[file] = "modified"
+		}
+	}
+
+	if len(changes) == 0 {
+		return plumbing.ZeroHash, false, nil
+	}
+
+	if message == "" {
+		message = "Export landscape"
+		if config.Client != nil {
+			message = fmt.Sprintf("Export landscape from %s", config.Client.Config.Host)
+		}
+	}
+
+	hash, err := w.Commit(message+"\n\n"+describeChanges(relDir, changes), &git.CommitOptions{Author: commitAuthor(repo)})
+	return hash, err == nil, err
+}
+
+// describeChanges returns a line per resource directory with the number of its added, modified and deleted files,
+// e.g. "kafka/topics: 2 added, 1 modified".
+func describeChanges(relDir string, changes map[string]string) string {
+	counts := make(map[string]map[string]int)
+	for file, change := range changes {
+		resource := strings.TrimPrefix(file, relDir+"/")
+		if d := filepath.ToSlash(filepath.Dir(resource)); d != "." {
+			resource = d
+		}
+
+		if counts[resource] == nil {
+			counts[resource] = make(map[string]int)
+		}
+		counts[resource][change]++
+	}
+
+	resources := make([]string, 0, len(counts))
+	for resource := range counts {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	var b strings.Builder
+	for _, resource := range resources {
+		var parts []string
+		for _, change := range []string{"added", "modified", "deleted"} {
+			if n := counts[resource][change]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", n, change))
+			}
+		}
+		fmt.Fprintf(&b, "%s: %s\n", resource, strings.Join(parts, ", "))
+	}
+
+	return b.String()
+}
+
+// commitAuthor returns the author of the commits from the environment, the repository or the global git configuration.
+func commitAuthor(repo *git.Repository) *object.Signature {
+	author := &object.Signature{
+		Name:  os.Getenv(envGitAuthorName),
+		Email: os.Getenv(envGitAuthorEmail),
+		When:  time.Now(),
+	}
+
+	sections := []*gitformat.Section{}
+	if cfg, err := repo.Config(); err == nil && cfg.Raw != nil {
+		sections = append(sections, cfg.Raw.Section("user"))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if f, err := os.Open(filepath.Join(home, ".gitconfig")); err == nil {
+			global := gitformat.New()
+			if gitformat.NewDecoder(f).Decode(global) == nil {
+				sections = append(sections, global.Section("user"))
+			}
+			f.Close()
+		}
+	}
+
+	for _, section := range sections {
+		if author.Name == "" {
+			author.Name = section.Option("name")
+		}
+		if author.Email == "" {
+			author.Email = section.Option("email")
+		}
+	}
+
+	if author.Name == "" {
+		author.Name = "lenses-cli"
+	}
+
+	return author
+}
+
+func pushBranch(repo *git.Repository, remoteName string) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+
+	if !head.Name().IsBranch() {
+		return fmt.Errorf("HEAD is not a branch")
+	}
+
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return err
+	}
+
+	var auth transport.AuthMethod
+	if username, password := os.Getenv(envGitUsername), os.Getenv(envGitPassword); password != "" {
+		if urls := remote.Config().URLs; len(urls) > 0 && strings.HasPrefix(urls[0], "http") {
+			auth = &http.BasicAuth{Username: username, Password: password}
+		}
+	}
+
+	refSpec := gitconfig.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+	err = repo.Push(&git.PushOptions{RemoteName: remoteName, RefSpecs: []gitconfig.RefSpec{refSpec}, Auth: auth})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	gitconfig "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestExportGitCommitAndPush(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-git", nil)
+	defer remove()
+
+	remoteDir, repoDir := filepath.Join(dir, "remote.git"), filepath.Join(dir, "repo")
+	_, err := git.PlainInit(remoteDir, true)
+	assert.Nil(t, err)
+	repo, err := git.PlainInit(repoDir, false)
+	assert.Nil(t, err)
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{remoteDir}})
+	assert.Nil(t, err)
+
+	// files outside of the landscape directory are not committed.
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(repoDir, "notes.txt"), []byte("draft"), 0666))
+
+	topics := `[{"topicName": "payments", "partitions": 3, "replication": 1, "configs": []}]`
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/topics" {
+			w.Write([]byte(topics))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	os.Setenv(envGitAuthorName, "Exporter")
+	os.Setenv(envGitAuthorEmail, "exporter@example.com")
+	defer os.Unsetenv(envGitAuthorName)
+	defer os.Unsetenv(envGitAuthorEmail)
+
+	landscape := filepath.Join(repoDir, "landscape")
+	export := func(args ...string) {
+		cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+		_, err := test.ExecuteCommand(cmd, append([]string{"topics", "--dir", landscape}, args...)...)
+		assert.Nil(t, err)
+	}
+
+	export("--git-branch", "export/{{.Resource}}", "--git-commit", "--message", "Export topics", "--git-push")
+
+	head, err := repo.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("export/topics"), head.Name())
+
+	commit, err := repo.CommitObject(head.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, "Export topics\n\n"+pkg.TopicsPath+": 1 added\n", commit.Message)
+	assert.Equal(t, "Exporter", commit.Author.Name)
+
+	files, err := commit.Files()
+	assert.Nil(t, err)
+	var names []string
+	files.ForEach(func(f *object.File) error {
+		names = append(names, f.Name)
+		return nil
+	})
+	assert.Equal(t, []string{"landscape/" + pkg.TopicsPath + "/topic-payments.yaml"}, names)
+
+	remote, err := git.PlainOpen(remoteDir)
+	assert.Nil(t, err)
+	pushed, err := remote.Reference(plumbing.NewBranchReferenceName("export/topics"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, head.Hash(), pushed.Hash())
+
+	// an export without changes does not commit.
+	export("--git-commit")
+	unchanged, err := repo.Head()
+	assert.Nil(t, err)
+	assert.Equal(t, head.Hash(), unchanged.Hash())
+
+	topics = strings.Replace(topics, `"partitions": 3`, `"partitions": 6`, 1)
+	export("--git-commit")
+	head, err = repo.Head()
+	assert.Nil(t, err)
+	commit, err = repo.CommitObject(head.Hash())
+	assert.Nil(t, err)
+	assert.True(t, strings.HasSuffix(commit.Message, "\n\n"+pkg.TopicsPath+": 1 modified\n"), commit.Message)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -21,24 +20,28 @@ func NewExportGroupsCommand() *cobra.Command {
 		Example:          `export groups`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeGroups(cmd, name); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeGroups(cmd, opts, name); err != nil {
 				golog.Errorf("Error writing Users. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().StringVar(&name, "name", "", "The group name to extract")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeGroups(cmd *cobra.Command, groupName string) error {
+func writeGroups(cmd *cobra.Command, opts *exportOptions, groupName string) error {
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
@@ -49,20 +52,26 @@ func writeGroups(cmd *cobra.Command, groupName string) error {
 		}
 
 		fileName := fmt.Sprintf("groups-%s.%s", strings.ToLower(group.Name), strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+		return opts.writeLandscapeFile(pkg.GroupsPath, fileName, output, group)
 	}
 	groups, err := config.Client.GetGroups()
 	if err != nil {
 		return err
 	}
 
+	opts.warnUnfiltered("groups", false, false)
+
 	for _, group := range groups {
+		if !opts.exported(filterable{name: group.Name}) {
+			continue
+		}
+
 		fileName := fmt.Sprintf("groups-%s.%s", strings.ToLower(group.Name), strings.ToLower(output))
 		if groupName != "" && group.Name == groupName {
-			return utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+			return opts.writeLandscapeFile(pkg.GroupsPath, fileName, output, group)
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+		err := opts.writeLandscapeFile(pkg.GroupsPath, fileName, output, group)
 		if err != nil {
 			return err
 		}
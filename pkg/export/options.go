@@ -0,0 +1,86 @@
+package export
+
+import (
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// exportOptions are the settings of a single export.
+type exportOptions struct {
+	dir               string
+	singleFile        string
+	dependents        bool
+	prefix            string
+	maskSecrets       bool
+	secretPlaceholder string
+	sqlFiles          bool
+	avscFiles         bool
+	connectorFormat   string
+
+	include, exclude, tags, owners []string
+
+	mode api.ExecutionMode
+
+	documents *exportDocuments
+	// manifest are the resources of the landscape manifest, written once the export is done, see `export all`.
+	manifest []exportResource
+}
+
+// newExportOptions returns the options of the flags of the running export command.
+func newExportOptions() *exportOptions {
+	return &exportOptions{
+		dir:               landscapeDir,
+		singleFile:        singleFile,
+		dependents:        dependents,
+		prefix:            prefix,
+		maskSecrets:       maskSecrets,
+		secretPlaceholder: secretPlaceholder,
+		sqlFiles:          sqlFiles,
+		avscFiles:         avscFiles,
+		connectorFormat:   connectorFormat,
+		include:           includePatterns,
+		exclude:           excludePatterns,
+		tags:              tagFilters,
+		owners:            ownerFilters,
+		documents:         new(exportDocuments),
+	}
+}
+
+// runExport returns the RunE of an export command, "export" writes the resources with the options of the command,
+// then the `--single-file`, the manifest, the `--bundle` and the git commit of the landscape are written.
+func runExport(export func(cmd *cobra.Command, args []string, opts *exportOptions) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		opts := newExportOptions()
+		if err := export(cmd, args, opts); err != nil {
+			return err
+		}
+
+		// the manifest records the checksum of the single file instead of the ones of the resource files.
+		if err := opts.writeDocuments(); err != nil {
+			return err
+		}
+
+		if opts.manifest != nil {
+			if err := writeManifest(cmd, config.Client, opts); err != nil {
+				return err
+			}
+		}
+
+		if err := bundleLandscape(cmd, opts); err != nil {
+			return err
+		}
+
+		return commitLandscape(cmd, opts.dir)
+	}
+}
+
+func (opts *exportOptions) setExecutionMode(client *api.Client) error {
+	mode, err := getExecutionMode(client)
+	if err != nil {
+		return err
+	}
+
+	opts.mode = mode
+	return nil
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,20 +22,24 @@ func NewExportPoliciesCommand() *cobra.Command {
 		Example:          `export policies --resource-name my-policy`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			client := config.Client
 
-			setExecutionMode(client)
+			opts.setExecutionMode(client)
 			checkFileFlags(cmd)
-			if err := writePolicies(cmd, client, name, ID); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writePolicies(cmd, client, opts, name, ID); err != nil {
 				golog.Errorf("Error writing policies. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The resource name to export")
 	cmd.Flags().StringVar(&ID, "id", "", "The policy id to extract")
@@ -45,8 +48,8 @@ func NewExportPoliciesCommand() *cobra.Command {
 	return cmd
 }
 
-func writePolicies(cmd *cobra.Command, client *api.Client, name string, ID string) error {
-	golog.Infof("Writing policies to [%s]", landscapeDir)
+func writePolicies(cmd *cobra.Command, client *api.Client, opts *exportOptions, name string, ID string) error {
+	golog.Infof("Writing policies to [%s]", opts.dir)
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
 	if ID != "" {
@@ -57,7 +60,7 @@ func writePolicies(cmd *cobra.Command, client *api.Client, name string, ID strin
 
 		fileName := fmt.Sprintf("policies-%s.%s", strings.ToLower(policy.Name), strings.ToLower(output))
 		request := client.PolicyAsRequest(policy)
-		return utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, request)
+		return opts.writeLandscapeFile(pkg.PoliciesPath, fileName, output, request)
 	}
 
 	policies, err := client.GetPolicies()
@@ -65,13 +68,20 @@ func writePolicies(cmd *cobra.Command, client *api.Client, name string, ID strin
 		return err
 	}
 
+	opts.warnUnfiltered("policies", false, false)
+
 	for _, policy := range policies {
+		if !opts.exported(filterable{name: policy.Name}) {
+			continue
+		}
+
 		fileName := fmt.Sprintf("policies-%s.%s", strings.ToLower(policy.Name), strings.ToLower(output))
+		request := client.PolicyAsRequest(policy)
 		if name != "" && policy.Name == name {
-			return utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, policy)
+			return opts.writeLandscapeFile(pkg.PoliciesPath, fileName, output, request)
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, policy)
+		err := opts.writeLandscapeFile(pkg.PoliciesPath, fileName, output, request)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestExportPoliciesAsRequests(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-policies", nil)
+	defer remove()
+
+	const policy = `{"id": "7", "name": "PII", "lastUpdated": "2020-12-01", "versions": 3, "impactType": "HIGH",
+  "category": "PII", "datasets": ["payments"], "fields": ["card"], "obfuscation": "First-1", "lastUpdatedUser": "admin"}`
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "IN_PROC"}`))
+		case "/api/protection/policy":
+			w.Write([]byte("[" + policy + "]"))
+		default:
+			w.Write([]byte(policy))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	// the policies are exported as the requests that import them, without their id, by name or by id.
+	for _, args := range [][]string{{}, {"--id", "7"}} {
+		cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+		_, err := test.ExecuteCommand(cmd, append([]string{"policies", "--dir", dir}, args...)...)
+		assert.Nil(t, err)
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, pkg.PoliciesPath, "policies-pii.yaml"))
+		assert.Nil(t, err)
+
+		var exported map[string]interface{}
+		assert.Nil(t, yaml.Unmarshal(b, &exported))
+		assert.NotContains(t, exported, "id")
+		assert.Equal(t, "PII", exported["name"])
+		assert.Equal(t, []interface{}{"payments"}, exported["datasets"])
+		assert.Equal(t, []interface{}{"card"}, exported["fields"])
+
+		var request api.DataPolicyRequest
+		assert.Nil(t, yaml.UnmarshalStrict(b, &request))
+		assert.Equal(t, "HIGH", request.ImpactType)
+		assert.Equal(t, "First-1", request.Obfuscation)
+		assert.Empty(t, request.LastUpdatedUser)
+	}
+}
@@ -2,6 +2,7 @@ package export
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/kataras/golog"
@@ -13,45 +14,59 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// sqlFiles writes the processors as plain `.sql` files with a metadata file next to them, see `--sql-files`.
+var sqlFiles bool
+
+// canSQLFiles registers the `--sql-files` flag to an export command.
+func canSQLFiles(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&sqlFiles, "sql-files", false, "Write the SQL of every processor to a plain <name>.sql file, next to a <name>.yaml file of its runners, cluster, namespace, pipeline and processorId")
+}
+
 //NewExportProcessorsCommand creates `export processors` command
 func NewExportProcessorsCommand() *cobra.Command {
 	var name, cluster, namespace, id string
 
 	cmd := &cobra.Command{
-		Use:              "processors",
-		Short:            "export processors",
-		Example:          `export processors --resource-name my-processor`,
+		Use:   "processors",
+		Short: "export processors",
+		Example: `export processors --resource-name my-processor
+export processors --dir my-dir --sql-files`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			client := config.Client
 
-			setExecutionMode(client)
+			opts.setExecutionMode(client)
 			checkFileFlags(cmd)
-			if err := writeProcessors(cmd, client, id, cluster, namespace, name); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeProcessors(cmd, client, opts, id, cluster, namespace, name); err != nil {
 				golog.Errorf("Error writing processors. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The processor name to export")
 	cmd.Flags().StringVar(&cluster, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Select by namespace, available only in KUBERNETES mode")
 	cmd.Flags().StringVar(&id, "id", "", "ID of the processor to export")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Processor with the prefix in the name only")
+	canSQLFiles(cmd)
 
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namespace, name string) error {
+func writeProcessors(cmd *cobra.Command, client *api.Client, opts *exportOptions, id, cluster, namespace, name string) error {
 
-	if mode == api.ExecutionModeInProcess {
+	if opts.mode == api.ExecutionModeInProcess {
 		cluster = "IN-PROC"
 		namespace = "Lenses"
 	}
@@ -60,6 +75,12 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 		return err
 	}
 
+	opts.warnUnfiltered("processors", false, true)
+
+	if opts.sqlFiles && opts.singleFile != "" {
+		golog.Warnf("Processors are written to the single file with their SQL, --sql-files is ignored")
+	}
+
 	for _, processor := range processors.Streams {
 		if id != "" && id != processor.ID {
 			continue
@@ -76,7 +97,11 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 				continue
 			}
 
-			if prefix != "" && !strings.HasPrefix(processor.Name, prefix) {
+			if opts.prefix != "" && !strings.HasPrefix(processor.Name, opts.prefix) {
+				continue
+			}
+
+			if !opts.exported(filterable{name: processor.Name, owner: processor.User, owned: true}) {
 				continue
 			}
 		}
@@ -90,9 +115,9 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 
 		var fileName string
 
-		if mode == api.ExecutionModeInProcess {
+		if opts.mode == api.ExecutionModeInProcess {
 			fileName = fmt.Sprintf("processor-%s.%s", strings.ToLower(processor.Name), strings.ToLower(output))
-		} else if mode == api.ExecutionModeConnect {
+		} else if opts.mode == api.ExecutionModeConnect {
 			fileName = fmt.Sprintf("processor-%s-%s.%s", strings.ToLower(processor.ClusterName), strings.ToLower(processor.Name), strings.ToLower(output))
 		} else {
 			fileName = fmt.Sprintf("processor-%s-%s-%s.%s", strings.ToLower(processor.ClusterName), strings.ToLower(processor.Namespace), strings.ToLower(processor.Name), strings.ToLower(output))
@@ -103,14 +128,24 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 		request.SQL = strings.Replace(request.SQL, "\t", "  ", -1)
 		request.SQL = strings.Replace(request.SQL, " \n", "\n", -1)
 
-		if err := utils.WriteFile(landscapeDir, pkg.SQLPath, fileName, output, request); err != nil {
+		if opts.sqlFiles && opts.singleFile == "" {
+			// the SQL goes to its own file so that it's reviewed as SQL, the rest of the processor to the metadata file.
+			sqlFileName := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".sql"
+			if err := utils.WriteBytesFile(opts.dir, pkg.SQLPath, sqlFileName, []byte(request.SQL+"\n")); err != nil {
+				return err
+			}
+			if err := opts.writeLandscapeFile(pkg.SQLPath, fileName, output, request.Metadata()); err != nil {
+				return err
+			}
+			fileName = sqlFileName
+		} else if err := opts.writeLandscapeFile(pkg.SQLPath, fileName, output, request); err != nil {
 			return err
 		}
-		if dependents {
-			handleDependents(cmd, client, processor.ID)
+		if opts.dependents {
+			handleDependents(cmd, client, opts, processor.ID)
 		}
 
-		exportPath := fmt.Sprintf("%s/%s/%s", landscapeDir, pkg.SQLPath, fileName)
+		exportPath := fmt.Sprintf("%s/%s/%s", opts.dir, pkg.SQLPath, fileName)
 		fmt.Printf("processor '%s' has been successfully exported at %s\n", request.Name, exportPath)
 	}
 
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportProcessorsSQLFiles(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-processors", nil)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.sql.execution.mode": "IN_PROC"}`))
+		default:
+			w.Write([]byte(`{"streams": [{"id": "1", "name": "Enrich", "runners": 2, "clusterName": "IN-PROC", "namespace": "Lenses",
+  "sql": "SET defaults.topic.autocreate=true;\n\tINSERT INTO enriched SELECT STREAM * FROM orders; ", "pipeline": "enrich"}]}`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+	defer func() { sqlFiles = false }()
+
+	cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "processors", "--dir", dir, "--sql-files")
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, pkg.SQLPath, "processor-enrich.sql"))
+	assert.Nil(t, err)
+	assert.Equal(t, "SET defaults.topic.autocreate=true;\n  INSERT INTO enriched SELECT STREAM * FROM orders;\n", string(b))
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, pkg.SQLPath, "processor-enrich.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `name: Enrich
+runnerCount: 2
+cluster: IN-PROC
+namespace: Lenses
+pipeline: enrich
+`, string(b))
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,25 +21,29 @@ func NewExportQuotasCommand() *cobra.Command {
 		Example:          `export quoats`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
 
-			if err := writeQuotas(cmd, config.Client); err != nil {
+			if err := writeQuotas(cmd, config.Client, opts); err != nil {
 				golog.Errorf("Error writing quotas. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
 
-func writeQuotas(cmd *cobra.Command, client *api.Client) error {
+func writeQuotas(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
 
 	quotas, err := client.GetQuotas()
 
@@ -52,9 +55,16 @@ func writeQuotas(cmd *cobra.Command, client *api.Client) error {
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("quotas.%s", strings.ToLower(output))
 
+	opts.warnUnfiltered("quotas", false, false)
+
+	// the quotas are filtered by the name of their user or client.
 	for _, q := range quotas {
+		if !opts.exported(filterable{name: q.EntityName}) {
+			continue
+		}
+
 		requests = append(requests, q.GetQuotaAsRequest())
 	}
 
-	return utils.WriteFile(landscapeDir, pkg.QuotasPath, fileName, output, requests)
+	return opts.writeLandscapeFile(pkg.QuotasPath, fileName, output, requests)
 }
@@ -1,7 +1,12 @@
 package export
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,6 +17,7 @@ import (
 	config "github.com/lensesio/lenses-go/pkg/configs"
 	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 //NewExportSchemasCommand creates `export schemas` command
@@ -19,13 +25,18 @@ func NewExportSchemasCommand() *cobra.Command {
 	var name, version string
 
 	cmd := &cobra.Command{
-		Use:              "schemas",
-		Short:            "export schemas",
-		Example:          `export schemas --resource-name my-schema-value --version 1. If no name is supplied the latest versions of all schemas are exported`,
+		Use:   "schemas",
+		Short: "export schemas",
+		Example: `export schemas --resource-name my-schema-value --version 1. If no name is supplied the latest versions of all schemas are exported
+export schemas --dir my-dir --avsc`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			opts.checkAvscFiles()
 
 			versionInt, err := strconv.Atoi(version)
 			if err != nil {
@@ -34,32 +45,45 @@ func NewExportSchemasCommand() *cobra.Command {
 			}
 
 			if name != "" {
-				if err := writeSchema(cmd, config.Client, name, versionInt); err != nil {
+				if err := writeSchema(cmd, config.Client, opts, name, versionInt); err != nil {
 					golog.Errorf("Error writing schema. [%s]", err.Error())
 					return err
 				}
 				return nil
 			}
 
-			if err := writeSchemas(cmd, config.Client); err != nil {
+			if err := writeSchemas(cmd, config.Client, opts); err != nil {
 				golog.Errorf("Error writing schemas. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The schema to export. Both the key schema and value schema are exported")
 	cmd.Flags().StringVar(&version, "version", "0", "The schema version to export.")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Schemas with the prefix only")
+	canAvscFiles(cmd)
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeSchemas(cmd *cobra.Command, client *api.Client) error {
+// avscFiles writes the schemas as `.avsc` files with a file mapping their subjects to them, see `--avsc`.
+var avscFiles bool
+
+// avscDir is the directory of the `.avsc` files, under the schemas directory of the landscape.
+const avscDir = "avro"
+
+// canAvscFiles registers the `--avsc` flag to an export command.
+func canAvscFiles(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&avscFiles, "avsc", false, "Write every schema to a pretty-printed .avsc file, named after its namespace and name, and the subjects to a "+api.SchemaSubjectsFileName+" file mapping them to their .avsc files")
+}
+
+func writeSchemas(cmd *cobra.Command, client *api.Client, opts *exportOptions) error {
 
 	subjects, err := client.GetSubjects()
 
@@ -67,38 +91,62 @@ func writeSchemas(cmd *cobra.Command, client *api.Client) error {
 		return err
 	}
 
+	opts.warnUnfiltered("schemas", false, false)
+
+	var schemas []api.Schema
 	for _, subject := range subjects {
-		if prefix != "" && !strings.HasPrefix(subject, prefix) {
+		if opts.prefix != "" && !strings.HasPrefix(subject, opts.prefix) {
+			continue
+		}
+
+		if !opts.exported(filterable{name: subject}) {
 			continue
 		}
 
 		// don't export control topics
-		excluded := false
-		for _, exclude := range systemTopicExclusions {
-			if strings.HasPrefix(subject, exclude) ||
-				strings.Contains(subject, "KSTREAM-") ||
-				strings.Contains(subject, "_agg_") ||
-				strings.Contains(subject, "_sql_store_") {
-				excluded = true
-				break
-			}
+		if utils.IsSystemTopic(subject) {
+			continue
+		}
+
+		schema, err := getSchema(client, subject, 0)
+		if err != nil {
+			golog.Error(fmt.Sprintf("Error while exporting schema [%s]", subject))
+			return err
 		}
 
-		if excluded {
+		if opts.writesAvscFiles() {
+			schemas = append(schemas, schema)
 			continue
 		}
 
-		if err := writeSchema(cmd, client, subject, 0); err != nil {
+		if err := writeSchemaFile(cmd, opts, schema); err != nil {
 			golog.Error(fmt.Sprintf("Error while exporting schema [%s]", subject))
 			return err
 		}
 	}
 
+	if opts.writesAvscFiles() {
+		return writeAvscFiles(cmd, opts, schemas, false)
+	}
+
 	return nil
 }
 
-func writeSchema(cmd *cobra.Command, client *api.Client, name string, version int) error {
-	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+func writeSchema(cmd *cobra.Command, client *api.Client, opts *exportOptions, name string, version int) error {
+	schema, err := getSchema(client, name, version)
+	if err != nil {
+		return err
+	}
+
+	if opts.writesAvscFiles() {
+		return writeAvscFiles(cmd, opts, []api.Schema{schema}, true)
+	}
+
+	return writeSchemaFile(cmd, opts, schema)
+}
+
+// getSchema returns a version of a schema, or its latest version if "version" is 0, pretty-printed.
+func getSchema(client *api.Client, name string, version int) (api.Schema, error) {
 	var schema api.Schema
 	var err error
 
@@ -108,18 +156,103 @@ func writeSchema(cmd *cobra.Command, client *api.Client, name string, version in
 		schema, err = client.GetLatestSchema(name)
 	}
 
+	if err != nil {
+		return schema, err
+	}
+
 	pretty, _ := utils.PrettyPrint([]byte(schema.AvroSchema))
 
 	schema.AvroSchema = string(pretty)
 	schema.AvroSchema = strings.TrimSpace(schema.AvroSchema)
 	schema.AvroSchema = strings.Replace(schema.AvroSchema, "\t", "  ", -1)
 	schema.AvroSchema = strings.Replace(schema.AvroSchema, " \n", "\n", -1)
+	return schema, nil
+}
 
-	if err != nil {
-		return err
+func writeSchemaFile(cmd *cobra.Command, opts *exportOptions, schema api.Schema) error {
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+	request := config.Client.GetSchemaAsRequest(schema)
+	fileName := fmt.Sprintf("schema-%s.%s", strings.ToLower(schema.Name), strings.ToLower(output))
+	return opts.writeLandscapeFile(pkg.SchemasPath, fileName, output, request)
+}
+
+// checkAvscFiles resolves `--avsc` against `--single-file`, the single file holds the schemas as documents.
+func (opts *exportOptions) checkAvscFiles() {
+	if opts.avscFiles && opts.singleFile != "" {
+		golog.Warnf("Schemas are written to the single file as documents, --avsc is ignored")
+		opts.avscFiles = false
+	}
+}
+
+// writesAvscFiles reports whether the schemas are written as `.avsc` files.
+func (opts *exportOptions) writesAvscFiles() bool {
+	return opts.avscFiles && opts.singleFile == ""
+}
+
+// writeAvscFiles writes the `.avsc` files of the schemas and the file mapping their subjects to them,
+// the subjects already in the mapping file are kept when "merge" is true.
+func writeAvscFiles(cmd *cobra.Command, opts *exportOptions, schemas []api.Schema, merge bool) error {
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+	mappingFileName := fmt.Sprintf("%s.%s", api.SchemaSubjectsFileName, strings.ToLower(output))
+
+	var mapping api.SchemaSubjectsFile
+	if merge {
+		if b, err := ioutil.ReadFile(filepath.Join(opts.dir, pkg.SchemasPath, mappingFileName)); err == nil {
+			if err = yaml.Unmarshal(b, &mapping); err != nil {
+				return fmt.Errorf("invalid subjects file [%s]. [%v]", filepath.Join(opts.dir, pkg.SchemasPath, mappingFileName), err)
+			}
+		}
+	}
+
+	bySubject := make(map[string]api.SchemaSubjectFile)
+	for _, subject := range mapping.Subjects {
+		bySubject[subject.Subject] = subject
+	}
+
+	// the subjects of the same schema share its file, e.g. the value schemas of the topics of the same records.
+	written := make(map[string]string)
+	for _, schema := range schemas {
+		file := path.Join(avscDir, avscFileName(schema.Name, schema.AvroSchema))
+		if content, ok := written[file]; ok && content != schema.AvroSchema {
+			file = path.Join(avscDir, schema.Name+".avsc")
+		}
+
+		if _, ok := written[file]; !ok {
+			dir := path.Join(pkg.SchemasPath, path.Dir(file))
+			if err := utils.WriteBytesFile(opts.dir, dir, path.Base(file), []byte(schema.AvroSchema+"\n")); err != nil {
+				return err
+			}
+			written[file] = schema.AvroSchema
+		}
+
+		bySubject[schema.Name] = api.SchemaSubjectFile{Subject: schema.Name, Version: schema.Version, File: file}
+	}
+
+	mapping.Subjects = make([]api.SchemaSubjectFile, 0, len(bySubject))
+	for _, subject := range bySubject {
+		mapping.Subjects = append(mapping.Subjects, subject)
+	}
+	sort.Slice(mapping.Subjects, func(i, j int) bool { return mapping.Subjects[i].Subject < mapping.Subjects[j].Subject })
+
+	return opts.writeLandscapeFile(pkg.SchemasPath, mappingFileName, output, mapping)
+}
+
+// avscFileName returns the path of the `.avsc` file of a named schema, e.g. "com/acme/Order.avsc" for the "com.acme.Order" record,
+// or of the subject for the other schemas.
+func avscFileName(subject, avroSchema string) string {
+	var named struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+
+	if err := json.Unmarshal([]byte(avroSchema), &named); err != nil || named.Name == "" {
+		return subject + ".avsc"
+	}
+
+	fullname := named.Name
+	if !strings.Contains(fullname, ".") && named.Namespace != "" {
+		fullname = named.Namespace + "." + fullname
 	}
 
-	request := client.GetSchemaAsRequest(schema)
-	fileName := fmt.Sprintf("schema-%s.%s", strings.ToLower(name), strings.ToLower(output))
-	return utils.WriteFile(landscapeDir, pkg.SchemasPath, fileName, output, request)
+	return path.Join(strings.Split(fullname, ".")...) + ".avsc"
 }
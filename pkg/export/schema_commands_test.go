@@ -0,0 +1,97 @@
+package export
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSchemasAvsc(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "export-schemas", nil)
+	defer remove()
+
+	schemas := map[string]string{
+		"orders-value":   `{"type":"record","name":"Order","namespace":"com.acme","fields":[{"name":"id","type":"string"}]}`,
+		"refunds-value":  `{"type":"record","name":"Order","namespace":"com.acme","fields":[{"name":"id","type":"string"}]}`,
+		"payments-value": `{"type":"record","name":"com.acme.Order","fields":[{"name":"id","type":"long"}]}`,
+		"orders-key":     `"string"`,
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/proxy-sr/subjects" {
+			w.Write([]byte(`["orders-key", "orders-value", "payments-value", "refunds-value"]`))
+			return
+		}
+
+		subject := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/proxy-sr/subjects/"), "/versions/latest")
+		fmt.Fprintf(w, `{"subject": %q, "version": 2, "id": 1, "schema": %q}`, subject, schemas[subject])
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+	defer func() { avscFiles = false }()
+
+	cmd := test.WithOutput(NewExportGroupCommand(), "yaml")
+	_, err := test.ExecuteCommand(cmd, "schemas", "--dir", dir, "--avsc")
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, pkg.SchemasPath, "avro", "com", "acme", "Order.avsc"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.acme",
+  "fields": [
+    {
+      "name": "id",
+      "type": "string"
+    }
+  ]
+}
+`, string(b))
+
+	// a different schema of the same name has the file of its subject.
+	_, err = os.Stat(filepath.Join(dir, pkg.SchemasPath, "avro", "payments-value.avsc"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(dir, pkg.SchemasPath, "schema-orders-value.yaml"))
+	assert.True(t, os.IsNotExist(err))
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, pkg.SchemasPath, "subjects.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `subjects:
+- subject: orders-key
+  version: 2
+  file: avro/orders-key.avsc
+- subject: orders-value
+  version: 2
+  file: avro/com/acme/Order.avsc
+- subject: payments-value
+  version: 2
+  file: avro/payments-value.avsc
+- subject: refunds-value
+  version: 2
+  file: avro/com/acme/Order.avsc
+`, string(b))
+}
+
+func TestCheckAvscFilesWithSingleFile(t *testing.T) {
+	opts := &exportOptions{avscFiles: true, singleFile: "landscape.yaml"}
+
+	// the check has no side effects, --avsc is resolved once by checkAvscFiles.
+	assert.False(t, opts.writesAvscFiles())
+	assert.True(t, opts.avscFiles)
+
+	opts.checkAvscFiles()
+	assert.False(t, opts.avscFiles)
+
+	opts = &exportOptions{avscFiles: true}
+	opts.checkAvscFiles()
+	assert.True(t, opts.writesAvscFiles())
+}
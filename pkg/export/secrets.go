@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var maskSecrets bool
+var secretPlaceholder string
+
+const defaultSecretPlaceholder = "${env:{{.Env}}}"
+
+// canMaskSecrets registers the `--mask-secrets` and `--secret-placeholder` flags to an export command.
+func canMaskSecrets(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&maskSecrets, "mask-secrets", false, "Replace the values of the sensitive keys, e.g. passwords and tokens, with secret placeholders resolved by the import")
+	cmd.Flags().StringVar(&secretPlaceholder, "secret-placeholder", defaultSecretPlaceholder,
+		"Template of the placeholders of --mask-secrets, with the fields .Kind, .Name, .Key and .Env, e.g. '${vault:secret/data/lenses/{{.Name}}#{{.Key}}}'")
+}
+
+// sensitiveKeyExpr matches the keys of the connector configs, connections and channel properties which hold credentials.
+var sensitiveKeyExpr = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|api[._-]?key|access[._-]?key|private[._-]?key|jaas\.config)`)
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeyExpr.MatchString(key)
+}
+
+// secretPlaceholderFields are the fields of the `--secret-placeholder` template,
+// "Env" is the upper case name of an environment variable made of the kind, the name and the key.
+type secretPlaceholderFields struct {
+	Kind string
+	Name string
+	Key  string
+	Env  string
+}
+
+var envNameExpr = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func (opts *exportOptions) maskedValue(kind, name, key string) (string, error) {
+	tmpl, err := template.New("secret-placeholder").Parse(opts.secretPlaceholder)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret placeholder [%s]. [%v]", opts.secretPlaceholder, err)
+	}
+
+	fields := secretPlaceholderFields{
+		Kind: kind,
+		Name: name,
+		Key:  key,
+		Env:  strings.Trim(strings.ToUpper(envNameExpr.ReplaceAllString(kind+"_"+name+"_"+key, "_")), "_"),
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, fields); err != nil {
+		return "", fmt.Errorf("invalid secret placeholder [%s]. [%v]", opts.secretPlaceholder, err)
+	}
+
+	return b.String(), nil
+}
+
+// isPlaceholder reports whether a value is already a placeholder, e.g. a `${file:...}` config provider of Kafka Connect.
+func isPlaceholder(s string) bool {
+	return strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}")
+}
+
+// maskConfig returns a copy of a connector config with its sensitive values replaced by placeholders.
+func (opts *exportOptions) maskConfig(kind, name string, config api.ConnectorConfig) (api.ConnectorConfig, error) {
+	if !opts.maskSecrets {
+		return config, nil
+	}
+
+	masked := make(api.ConnectorConfig, len(config))
+	for key, value := range config {
+		if s, ok := value.(string); ok && s != "" && isSensitiveKey(key) && !isPlaceholder(s) {
+			placeholder, err := opts.maskedValue(kind, name, key)
+			if err != nil {
+				return nil, err
+			}
+			value = placeholder
+		}
+		masked[key] = value
+	}
+
+	return masked, nil
+}
+
+// maskConnectionConfig returns a copy of the configuration of a connection with its sensitive values replaced by placeholders.
+func (opts *exportOptions) maskConnectionConfig(name string, config []api.ConnectionConfig) ([]api.ConnectionConfig, error) {
+	if !opts.maskSecrets {
+		return config, nil
+	}
+
+	masked := make([]api.ConnectionConfig, len(config))
+	for i, kv := range config {
+		if s, ok := kv.Value.(string); ok && s != "" && isSensitiveKey(kv.Key) && !isPlaceholder(s) {
+			placeholder, err := opts.maskedValue("connection", name, kv.Key)
+			if err != nil {
+				return nil, err
+			}
+			kv.Value = placeholder
+		}
+		masked[i] = kv
+	}
+
+	return masked, nil
+}
+
+// maskProperties returns a copy of the `{key, value}` properties of a channel with their sensitive values replaced by placeholders.
+func (opts *exportOptions) maskProperties(kind, name string, properties []api.KV) ([]api.KV, error) {
+	if !opts.maskSecrets {
+		return properties, nil
+	}
+
+	masked := make([]api.KV, len(properties))
+	for i, property := range properties {
+		key, _ := property["key"].(string)
+		value, isString := property["value"].(string)
+
+		copied := make(api.KV, len(property))
+		for k, v := range property {
+			copied[k] = v
+		}
+
+		if isString && value != "" && isSensitiveKey(key) && !isPlaceholder(value) {
+			placeholder, err := opts.maskedValue(kind, name, key)
+			if err != nil {
+				return nil, err
+			}
+			copied["value"] = placeholder
+		}
+		masked[i] = copied
+	}
+
+	return masked, nil
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	opts := &exportOptions{maskSecrets: true, secretPlaceholder: defaultSecretPlaceholder}
+
+	config := api.ConnectorConfig{
+		"connector.class":                    "io.confluent.connect.jdbc.JdbcSinkConnector",
+		"connection.password":                "s3cr3t",
+		"key.converter":                      "org.apache.kafka.connect.storage.StringConverter",
+		"aws.secret.key":                     "abc",
+		"ssl.truststore.password":            "${file:/secrets.properties:truststore}",
+		"consumer.override.sasl.jaas.config": "org.apache.kafka.common.security.plain.PlainLoginModule required;",
+	}
+
+	masked, err := opts.maskConfig("connector", "dev-jdbc.sink", config)
+	assert.Nil(t, err)
+	assert.Equal(t, api.ConnectorConfig{
+		"connector.class":                    "io.confluent.connect.jdbc.JdbcSinkConnector",
+		"connection.password":                "${env:CONNECTOR_DEV_JDBC_SINK_CONNECTION_PASSWORD}",
+		"key.converter":                      "org.apache.kafka.connect.storage.StringConverter",
+		"aws.secret.key":                     "${env:CONNECTOR_DEV_JDBC_SINK_AWS_SECRET_KEY}",
+		"ssl.truststore.password":            "${file:/secrets.properties:truststore}",
+		"consumer.override.sasl.jaas.config": "${env:CONNECTOR_DEV_JDBC_SINK_CONSUMER_OVERRIDE_SASL_JAAS_CONFIG}",
+	}, masked)
+	assert.Equal(t, "s3cr3t", config["connection.password"])
+
+	opts.secretPlaceholder = "${vault:secret/data/lenses/{{.Name}}#{{.Key}}}"
+	connection, err := opts.maskConnectionConfig("kafka", []api.ConnectionConfig{
+		{Key: "protocol", Value: "SASL_SSL"},
+		{Key: "sslKeystorePassword", Value: "changeit"},
+		{Key: "kafkaBootstrapServers", Value: []string{"broker:9093"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []api.ConnectionConfig{
+		{Key: "protocol", Value: "SASL_SSL"},
+		{Key: "sslKeystorePassword", Value: "${vault:secret/data/lenses/kafka#sslKeystorePassword}"},
+		{Key: "kafkaBootstrapServers", Value: []string{"broker:9093"}},
+	}, connection)
+
+	properties, err := opts.maskProperties("alert-channel", "slack", []api.KV{{"key": "webhookUrl", "value": "https://hooks"}, {"key": "apiKey", "value": "xoxb"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []api.KV{{"key": "webhookUrl", "value": "https://hooks"}, {"key": "apiKey", "value": "${vault:secret/data/lenses/slack#apiKey}"}}, properties)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -21,25 +20,29 @@ func NewExportServiceAccountsCommand() *cobra.Command {
 		Example:          `export serviceaccounts`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
 
-			if err := writeServiceAccounts(cmd, name); err != nil {
+			if err := writeServiceAccounts(cmd, opts, name); err != nil {
 				golog.Errorf("Error writing service accounts. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().StringVar(&name, "name", "", "The service account name to extract")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeServiceAccounts(cmd *cobra.Command, accountName string) error {
+func writeServiceAccounts(cmd *cobra.Command, opts *exportOptions, accountName string) error {
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	if accountName != "" {
@@ -49,20 +52,26 @@ func writeServiceAccounts(cmd *cobra.Command, accountName string) error {
 		}
 
 		fileName := fmt.Sprintf("svc-accounts-%s.%s", strings.ToLower(svcAcc.Name), strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+		return opts.writeLandscapeFile(pkg.ServiceAccountsPath, fileName, output, svcAcc)
 	}
 	svcaccs, err := config.Client.GetServiceAccounts()
 	if err != nil {
 		return err
 	}
 
+	opts.warnUnfiltered("service accounts", false, true)
+
 	for _, svcAcc := range svcaccs {
+		if !opts.exported(filterable{name: svcAcc.Name, owner: svcAcc.Owner, owned: true}) {
+			continue
+		}
+
 		fileName := fmt.Sprintf("svc-accounts-%s.%s", strings.ToLower(svcAcc.Name), strings.ToLower(output))
 		if accountName != "" && svcAcc.Name == accountName {
-			return utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+			return opts.writeLandscapeFile(pkg.ServiceAccountsPath, fileName, output, svcAcc)
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+		err := opts.writeLandscapeFile(pkg.ServiceAccountsPath, fileName, output, svcAcc)
 		if err != nil {
 			return err
 		}
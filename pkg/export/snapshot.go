@@ -0,0 +1,91 @@
+package export
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// SnapshotResources returns the names of the resources a snapshot can capture, see Snapshot.
+func SnapshotResources() []string {
+	var names []string
+	for _, resource := range exportResources() {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+// Snapshot exports the live state of the "resources", e.g. "topics", to the "dir" landscape, unfiltered and unmasked,
+// its files are readable by their owner only.
+func Snapshot(client *api.Client, dir string, resources []string) error {
+	byName := make(map[string]exportResource)
+	for _, resource := range exportResources() {
+		byName[resource.Name] = resource
+	}
+
+	var selected []exportResource
+	for _, name := range resources {
+		resource, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unable to snapshot [%s], it is not an exported resource", name)
+		}
+		selected = append(selected, resource)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+
+	// the zero options do not filter or mask anything and write a file per resource.
+	opts := &exportOptions{dir: dir, connectorFormat: connectorFormatLenses, documents: new(exportDocuments)}
+
+	cmd := &cobra.Command{Use: "snapshot"}
+	cmd.Flags().String(bite.GetOutPutFlagKey(), "YAML", "")
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	cmd.SetOut(ioutil.Discard)
+
+	for _, resource := range selected {
+		if resource.Name == "processors" || resource.Name == "connectors" || resource.Name == "policies" {
+			opts.setExecutionMode(client)
+			break
+		}
+	}
+
+	var failed []string
+	for _, resource := range selected {
+		if err := resource.write(cmd, client, opts); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", resource.Name, err))
+		}
+	}
+
+	if err := restrictPermissions(dir); err != nil {
+		return fmt.Errorf("failed to restrict the permissions of the snapshot [%s]. [%v]", dir, err)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to snapshot [%s]", strings.Join(failed, "], ["))
+	}
+
+	return nil
+}
+
+// restrictPermissions makes the files under "dir" readable and writable by their owner only, 0600, and its directories 0700.
+func restrictPermissions(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		mode := os.FileMode(0600)
+		if info.IsDir() {
+			mode = 0700
+		}
+
+		return os.Chmod(path, mode)
+	})
+}
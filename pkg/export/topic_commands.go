@@ -17,32 +17,36 @@ import (
 func NewExportTopicsCommand() *cobra.Command {
 	var name string
 	cmd := &cobra.Command{
-		Use:              "topics",
-		Short:            "export topics",
-		Example:          `export topics --resource-name my-topic`,
+		Use:   "topics",
+		Short: "export topics",
+		Example: `export topics --resource-name my-topic
+export topics --include='orders-*' --exclude='/-(dlq|retry)$/' --tag=payments`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: runExport(func(cmd *cobra.Command, args []string, opts *exportOptions) error {
 			checkFileFlags(cmd)
-			if err := writeTopics(cmd, config.Client, name); err != nil {
+			if err := opts.checkFilters(); err != nil {
+				return err
+			}
+			if err := writeTopics(cmd, config.Client, opts, name); err != nil {
 				golog.Errorf("Error writing topics. [%s]", err.Error())
 				return err
 			}
 			return nil
-		},
+		}),
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	canFilter(cmd)
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The topic name to export")
-	cmd.Flags().StringVar(&topicExclusions, "exclude", "", "Topics to exclude")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Topics with the prefix only")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func writeTopics(cmd *cobra.Command, client *api.Client, topicName string) error {
+func writeTopics(cmd *cobra.Command, client *api.Client, opts *exportOptions, topicName string) error {
 	var requests []api.CreateTopicPayload
 
 	raw, err := client.GetTopics()
@@ -51,55 +55,42 @@ func writeTopics(cmd *cobra.Command, client *api.Client, topicName string) error
 		return err
 	}
 
+	opts.warnUnfiltered("topics", true, false)
+
 	for _, topic := range raw {
 
 		// don't export control topics
-		excluded := false
-		for _, exclude := range systemTopicExclusions {
-			if strings.HasPrefix(topic.TopicName, exclude) ||
-				strings.Contains(topic.TopicName, "KSTREAM-") ||
-				strings.Contains(topic.TopicName, "_agg_") ||
-				strings.Contains(topic.TopicName, "_sql_store_") {
-				excluded = true
-				break
-			}
-		}
-
-		if excluded {
+		if utils.IsSystemTopic(topic.TopicName) {
 			continue
 		}
 
-		// exclude any user defined
-		excluded = false
-		for _, exclude := range strings.Split(topicExclusions, ",") {
-			if topic.TopicName == exclude {
-				excluded = true
-				break
-			}
+		topicTags := make([]string, 0, len(topic.Tags))
+		for _, tag := range topic.Tags {
+			topicTags = append(topicTags, tag.Name)
 		}
 
-		if excluded {
+		if !opts.exported(filterable{name: topic.TopicName, tags: topicTags, tagged: true}) {
 			continue
 		}
 
-		if prefix != "" && !strings.HasPrefix(topic.TopicName, prefix) {
+		if opts.prefix != "" && !strings.HasPrefix(topic.TopicName, opts.prefix) {
 			continue
 		}
 
 		if topicName != "" && topicName == topic.TopicName {
-			overrides := getTopicConfigOverrides(topic.Configs)
+			overrides := topic.ConfigOverrides()
 			request := topic.GetTopicAsRequest(overrides)
-			return writeTopicsAsRequest(cmd, []api.CreateTopicPayload{request})
+			return writeTopicsAsRequest(cmd, opts, []api.CreateTopicPayload{request})
 		}
 
-		overrides := getTopicConfigOverrides(topic.Configs)
+		overrides := topic.ConfigOverrides()
 		requests = append(requests, topic.GetTopicAsRequest(overrides))
 	}
 
-	return writeTopicsAsRequest(cmd, requests)
+	return writeTopicsAsRequest(cmd, opts, requests)
 }
 
-func writeTopicsAsRequest(cmd *cobra.Command, requests []api.CreateTopicPayload) error {
+func writeTopicsAsRequest(cmd *cobra.Command, opts *exportOptions, requests []api.CreateTopicPayload) error {
 	// write topics
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
@@ -107,33 +98,10 @@ func writeTopicsAsRequest(cmd *cobra.Command, requests []api.CreateTopicPayload)
 
 		fileName := fmt.Sprintf("topic-%s.%s", strings.ToLower(topic.TopicName), strings.ToLower(output))
 
-		if err := utils.WriteFile(landscapeDir, pkg.TopicsPath, fileName, output, topic); err != nil {
+		if err := opts.writeLandscapeFile(pkg.TopicsPath, fileName, output, topic); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
-
-func getTopicConfigOverrides(configs []api.KV) api.KV {
-	overrides := make(api.KV)
-
-	for _, kv := range configs {
-		if val, ok := kv["isDefault"]; ok {
-			if val.(bool) == false {
-				var name, value string
-
-				if val, ok := kv["name"]; ok {
-					name = val.(string)
-				}
-
-				if val, ok := kv["originalValue"]; ok {
-					value = val.(string)
-				}
-				overrides[name] = value
-			}
-		}
-	}
-
-	return overrides
-}
@@ -9,12 +9,9 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-var acl api.ACL
-
 //NewImportAclsCommand creates `import acls` command
 func NewImportAclsCommand() *cobra.Command {
 	var path string
@@ -27,66 +24,115 @@ func NewImportAclsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.AclsPath)
-			if err := loadAcls(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.AclsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.AclsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planAcls(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load acls. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadAcls(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planAcls(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading acls from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	knownACLs, err := client.GetACLs()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var (
+		changes   []planChange
+		described []api.ACL
+	)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
 		var candidateACLs []api.ACL
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &candidateACLs); err != nil {
+		if err := load(opts, importFilePath, &candidateACLs); err != nil {
+			if change, ok := invalidFile(cmd, "acl", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
-		var found, imported bool
-		// Import only new ACLs
+		// ACLs have no identity other than all of their fields, they are either known or new.
 		for _, candidateACL := range candidateACLs {
+			candidateACL := candidateACL
+
+			var live interface{}
 			for _, knownACL := range knownACLs {
 				if reflect.DeepEqual(knownACL, candidateACL) {
-					found = true
+					live = knownACL
+					break
 				}
 			}
 
-			if found {
-				continue
+			change, err := opts.newChange("acl", aclName(candidateACL), importFilePath, live, candidateACL, func() error {
+				if err := client.CreateOrUpdateACL(candidateACL); err != nil {
+					return fmt.Errorf("error creating/updating acl from [%s] [%s]", loadpath, err.Error())
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
 			}
 
-			if err := client.CreateOrUpdateACL(candidateACL); err != nil {
-				return fmt.Errorf("error creating/updating acl from [%s] [%s]", loadpath, err.Error())
-			}
-			fmt.Fprintf(cmd.OutOrStdout(), "imported ACL [%s] successfully\n", candidateACL)
+			changes = append(changes, change)
+			described = append(described, candidateACL)
+		}
+	}
 
-			imported = true
+	prune := pruneOptionsFrom(cmd)
+	for _, knownACL := range knownACLs {
+		knownACL := knownACL
 
+		found := false
+		for _, candidateACL := range described {
+			if reflect.DeepEqual(knownACL, candidateACL) {
+				found = true
+				break
+			}
 		}
 
-		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
-		if !imported {
-			fmt.Fprintf(cmd.OutOrStdout(), "no new ACLs have been found for import from %s\n", importFilePath)
+		if found {
+			continue
+		}
+
+		if change, ok := prune.deleteChange("acl", aclName(knownACL), nil, func() error {
+			return client.DeleteACL(knownACL)
+		}); ok {
+			changes = append(changes, change)
 		}
 	}
-	return nil
+
+	return changes, nil
+}
+
+func aclName(acl api.ACL) string {
+	return fmt.Sprintf("%s %s %s on %s:%s from %s", acl.Principal, acl.PermissionType, acl.Operation, acl.ResourceType, acl.ResourceName, acl.Host)
 }
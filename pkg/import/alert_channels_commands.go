@@ -21,18 +21,25 @@ func NewImportAlertChannelsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, "alert-channels")
+			checkManifest(config.Client, path, pkg.AlertChannelsFilePath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.AlertChannelsFilePath)
 
-			err := importChannels(config.Client, cmd, path, "alert", pkg.AlertChannelsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planChannels(config.Client, cmd, opts, loadpath, "alert", pkg.AlertChannelsPath)
 			if err != nil {
 				return fmt.Errorf("error importing alert channels. [%v]", err)
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
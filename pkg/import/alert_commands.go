@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
@@ -25,32 +26,50 @@ func NewImportAlertSettingsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.AlertSettingsPath)
+			checkManifest(config.Client, path, pkg.AlertSettingsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.AlertSettingsPath)
 
-			if err := loadProducerAlertSettings(config.Client, cmd, path); err != nil {
-				return fmt.Errorf("failed to load alert-settings for data produced. [%s]", err.Error())
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
 			}
-
-			if err := loadConsumerAlertSettings(config.Client, cmd, path); err != nil {
-				return fmt.Errorf("failed to load alert-settings for consumer rules. [%s]", err.Error())
+			changes, err := planAlertSettings(config.Client, cmd, opts, loadpath)
+			if err != nil {
+				return err
 			}
 
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadConsumerAlertSettings(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planAlertSettings(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+	producerChanges, err := planProducerAlertSettings(client, cmd, opts, loadpath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert-settings for data produced. [%s]", err.Error())
+	}
+
+	consumerChanges, err := planConsumerAlertSettings(client, cmd, opts, loadpath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert-settings for consumer rules. [%s]", err.Error())
+	}
+
+	return append(producerChanges, consumerChanges...), nil
+}
+
+func planConsumerAlertSettings(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	settings, err := client.GetAlertSetting(2000)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var existingConsumerAlertSettings api.ConsumerAlertSettings
@@ -72,57 +91,52 @@ func loadConsumerAlertSettings(client *api.Client, cmd *cobra.Command, loadpath
 
 	var targetConsumerAlertSettings api.ConsumerAlertSettings
 	channels, err := client.GetChannels(pkg.AlertChannelsPath, 1, 99999, "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
 
-	if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, "alert-setting-consumer.yaml"), &targetConsumerAlertSettings); err != nil {
-		return fmt.Errorf("error loading file [%s]", loadpath)
+	importFilePath := fmt.Sprintf("%s/%s", loadpath, "alert-setting-consumer.yaml")
+	if err := load(opts, importFilePath, &targetConsumerAlertSettings); err != nil {
+		return nil, fmt.Errorf("error loading file [%s]", loadpath)
 	}
-	fmt.Fprintf(cmd.OutOrStdout(), "loading alert conditions from alert-setting-consumer.yaml\n")
+	golog.Infof("Loading alert conditions from [%s]", importFilePath)
 
+	var changes []planChange
 	for _, targetCondition := range targetConsumerAlertSettings.ConditionDetails {
-		var found bool
+		targetCondition := targetCondition
 
+		// Conditions have no identity other than all of their fields, they are either known or new.
+		var live interface{}
 		for _, existingCondition := range existingConsumerAlertSettings.ConditionDetails {
 			if existingCondition.Channels == nil {
 				existingCondition.Channels = []string{}
 			}
 
 			if reflect.DeepEqual(targetCondition, existingCondition) {
-				found = true
+				live = existingCondition
+				break
 			}
 		}
-		if found {
-			continue
-		}
-
-		targetConditionForLog := targetCondition
-		targetConditionForLog.Channels = make([]string, len(targetCondition.Channels))
-		copy(targetConditionForLog.Channels, targetCondition.Channels)
-
-		for i, targetChann := range targetCondition.Channels {
-			for _, chann := range channels.Values {
-				if targetChann == chann.Name {
-					targetCondition.Channels[i] = chann.ID
-				}
-			}
-		}
-
-		err := config.Client.SetAlertSettingsConsumerCondition(strconv.Itoa(2000),
-			api.ConsumerAlertConditionRequestv1{Condition: targetCondition.Condition, Channels: targetCondition.Channels})
 
+		name := fmt.Sprintf("consumer group %s on topic %s", targetCondition.Condition.Group, targetCondition.Condition.Topic)
+		change, err := opts.newChange("alert condition", name, importFilePath, live, targetCondition, func() error {
+			return client.SetAlertSettingsConsumerCondition(strconv.Itoa(2000),
+				api.ConsumerAlertConditionRequestv1{Condition: targetCondition.Condition, Channels: channelIDs(channels, targetCondition.Channels)})
+		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "created/updated condition: [%v]\n", targetConditionForLog)
+		changes = append(changes, change)
 	}
 
-	return nil
+	return changes, nil
 }
 
-func loadProducerAlertSettings(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planProducerAlertSettings(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	settings, err := client.GetAlertSetting(5000)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var existingProducerAlertSettings api.ProducerAlertSettings
@@ -144,52 +158,63 @@ func loadProducerAlertSettings(client *api.Client, cmd *cobra.Command, loadpath
 
 	var targetProducerAlertSettings api.ProducerAlertSettings
 	channels, err := client.GetChannels(pkg.AlertChannelsPath, 1, 99999, "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
 
-	if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, "alert-setting-producer.yaml"), &targetProducerAlertSettings); err != nil {
-		return fmt.Errorf("error loading file [%s]", loadpath)
+	importFilePath := fmt.Sprintf("%s/%s", loadpath, "alert-setting-producer.yaml")
+	if err := load(opts, importFilePath, &targetProducerAlertSettings); err != nil {
+		return nil, fmt.Errorf("error loading file [%s]", loadpath)
 	}
-	fmt.Fprintf(cmd.OutOrStdout(), "loading alert conditions from alert-setting-producer.yaml\n")
+	golog.Infof("Loading alert conditions from [%s]", importFilePath)
 
+	var changes []planChange
 	for _, targetCondition := range targetProducerAlertSettings.ConditionDetails {
-		var found bool
+		targetCondition := targetCondition
 
+		var live interface{}
 		for _, existingCondition := range existingProducerAlertSettings.ConditionDetails {
 			if existingCondition.Channels == nil {
 				existingCondition.Channels = []string{}
 			}
 
 			if reflect.DeepEqual(targetCondition, existingCondition) {
-				found = true
+				live = existingCondition
+				break
 			}
 		}
-		if found {
-			continue
+
+		name := fmt.Sprintf("data produced on %s", targetCondition.Condition.DatasetName)
+		change, err := opts.newChange("alert condition", name, importFilePath, live, targetCondition, func() error {
+			return client.SetAlertSettingsProducerCondition(
+				strconv.Itoa(5000), "",
+				targetCondition.Condition.DatasetName,
+				targetCondition.Condition.Threshold,
+				targetCondition.Condition.Duration,
+				channelIDs(channels, targetCondition.Channels))
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		targetConditionForLog := targetCondition
-		targetConditionForLog.Channels = make([]string, len(targetCondition.Channels))
-		copy(targetConditionForLog.Channels, targetCondition.Channels)
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
 
-		for i, targetChann := range targetCondition.Channels {
-			for _, chann := range channels.Values {
-				if targetChann == chann.Name {
-					targetCondition.Channels[i] = chann.ID
-				}
-			}
-		}
-		err := config.Client.SetAlertSettingsProducerCondition(
-			strconv.Itoa(5000), "",
-			targetCondition.Condition.DatasetName,
-			targetCondition.Condition.Threshold,
-			targetCondition.Condition.Duration,
-			targetCondition.Channels)
+// channelIDs maps the channel names of an alert condition to their IDs.
+func channelIDs(channels api.ChannelResponse, names []string) []string {
+	ids := make([]string, len(names))
+	copy(ids, names)
 
-		if err != nil {
-			return err
+	for i, name := range names {
+		for _, chann := range channels.Values {
+			if name == chann.Name {
+				ids[i] = chann.ID
+			}
 		}
-
-		fmt.Fprintf(cmd.OutOrStdout(), "created/updated condition: [%v]\n", targetConditionForLog)
 	}
 
-	return nil
+	return ids
 }
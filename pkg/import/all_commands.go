@@ -0,0 +1,217 @@
+package imports
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// landscapeResource is a resource directory of a landscape and the way to plan its import.
+type landscapeResource struct {
+	Name string
+	Path string
+	plan func(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error)
+}
+
+// landscapeResources returns the resource directories of a landscape in the order they should be imported.
+func landscapeResources(interval string, retries int) []landscapeResource {
+	return []landscapeResource{
+		{Name: "connections", Path: pkg.ConnectionsFilePath, plan: planConnections},
+		{Name: "alert-channels", Path: pkg.AlertChannelsFilePath, plan: func(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+			return planChannels(client, cmd, opts, loadpath, "alert", pkg.AlertChannelsPath)
+		}},
+		{Name: "audit-channels", Path: pkg.AuditChannelsFilePath, plan: func(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+			return planChannels(client, cmd, opts, loadpath, "audit", pkg.AuditChannelsPath)
+		}},
+		{Name: "topics", Path: pkg.TopicsPath, plan: planTopics},
+		{Name: "schemas", Path: pkg.SchemasPath, plan: planSchemas},
+		{Name: "acls", Path: pkg.AclsPath, plan: planAcls},
+		{Name: "quotas", Path: pkg.QuotasPath, plan: planQuotas},
+		{Name: "broker-configs", Path: pkg.BrokerConfigsPath, plan: planBrokerConfigs},
+		{Name: "groups", Path: pkg.GroupsPath, plan: planGroups},
+		{Name: "serviceaccounts", Path: pkg.ServiceAccountsPath, plan: planServiceAccounts},
+		{Name: "policies", Path: pkg.PoliciesPath, plan: planPolicies},
+		{Name: "processors", Path: pkg.SQLPath, plan: planProcessors},
+		{Name: "connectors", Path: pkg.ConnectorsPath, plan: func(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+			return planConnectors(client, cmd, opts, loadpath, interval, retries)
+		}},
+		{Name: "alert-settings", Path: pkg.AlertSettingsPath, plan: planAlertSettings},
+		{Name: "datasets", Path: pkg.DatasetsPath, plan: planDatasets},
+	}
+}
+
+//NewImportAllCommand creates `import all` command
+func NewImportAllCommand() *cobra.Command {
+	var (
+		path, interval string
+		retries        int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "all",
+		Aliases: []string{"apply"},
+		Short:   "Import every resource directory of a landscape in dependency order",
+		Example: `
+import all --dir my-landscape
+import apply --dir my-landscape --dry-run --detailed-exitcode
+import apply --dir landscape.yaml
+import apply --dir my-landscape --concurrency 10 --ignore-errors
+import apply --dir my-landscape --overlay overlays/prod --values values-prod.yaml --set topics.partitions=12
+import apply --dir my-landscape --prune --prune-protect='_*' --prune-owner=platform --yes
+VAULT_ADDR=https://vault:8200 VAULT_TOKEN=xxx import apply --dir my-landscape # resolves ${vault:secret/data/db#password}`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+
+			resources, err := discoverResources(path, opts.overlay, landscapeResources(interval, retries))
+			if err != nil {
+				return err
+			}
+
+			paths := make([]string, 0, len(resources))
+			for _, resource := range resources {
+				paths = append(paths, resource.Path)
+			}
+			checkManifest(config.Client, path, paths...)
+
+			if dryRun, _ := cmd.Flags().GetBool(dryRunFlagKey); dryRun {
+				var changes []planChange
+				for _, resource := range resources {
+					resourceChanges, err := resource.plan(config.Client, cmd, opts, filepath.Join(path, resource.Path))
+					if err != nil {
+						return fmt.Errorf("failed to load %s. [%v]", resource.Name, err)
+					}
+					changes = append(changes, resourceChanges...)
+				}
+
+				return runPlan(cmd, changes)
+			}
+
+			names := make([]string, 0, len(resources))
+			for _, resource := range resources {
+				names = append(names, resource.Name)
+			}
+			snapshot, err := takeSnapshot(config.Client, cmd, path, names)
+			if err != nil {
+				return err
+			}
+
+			results, err := importResources(config.Client, cmd, opts, path, resources, snapshot)
+			if err != nil {
+				snapshot.warnRollback()
+			}
+			if len(results) > 0 {
+				if printErr := bite.PrintObject(cmd, results); printErr != nil {
+					return printErr
+				}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory of the landscape to import")
+	cmd.Flags().StringVar(&interval, "interval", "0s", "Time between importing two connectors")
+	cmd.Flags().IntVar(&retries, "retries", 5, "Number of HTTP retries of connectors before exiting")
+	canConnectCluster(cmd)
+
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
+
+// discoverResources returns the resources whose directory exists under the landscape directory or its overlay, if any,
+// or which have documents in the multi-document landscape files.
+func discoverResources(dir, overlay string, resources []landscapeResource) ([]landscapeResource, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("landscape directory [%s] does not exist", dir)
+	}
+
+	documents, err := readDocuments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	isDir := func(path string) bool {
+		info, err := os.Stat(path)
+		return err == nil && info.IsDir()
+	}
+
+	var found []landscapeResource
+	for _, resource := range resources {
+		if !isDir(filepath.Join(dir, resource.Path)) && (overlay == "" || !isDir(filepath.Join(overlay, resource.Path))) && len(documents[resource.Path]) == 0 {
+			golog.Debugf("No [%s] directory found in [%s]", resource.Path, dir)
+			continue
+		}
+
+		found = append(found, resource)
+	}
+
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no resource directories found in landscape [%s]", dir)
+	}
+
+	return found, nil
+}
+
+// importResources plans and applies the resources one after the other,
+// the ones after a failed one are skipped unless `--ignore-errors` is set.
+func importResources(client *api.Client, cmd *cobra.Command, opts overlayOptions, dir string, resources []landscapeResource, snapshot *importSnapshot) ([]applyResult, error) {
+	ignoreErrors, _ := cmd.Flags().GetBool(ignoreErrorsFlagKey)
+
+	var (
+		results []applyResult
+		failed  []string
+	)
+
+	for _, resource := range resources {
+		if len(failed) > 0 && !ignoreErrors {
+			results = append(results, applyResult{Kind: resource.Name, Status: applySkipped})
+			continue
+		}
+
+		changes, err := resource.plan(client, cmd, opts, filepath.Join(dir, resource.Path))
+		plan := newImportPlan(changes)
+		if err == nil {
+			err = confirmDeletes(cmd, plan)
+		}
+		if err == nil {
+			err = snapshot.recordCreated(resource.Name, plan.Changes)
+		}
+
+		if err != nil {
+			golog.Errorf("Failed to import %s. [%s]", resource.Name, err.Error())
+			results = append(results, applyResult{Kind: resource.Name, Status: applyFailed, Error: err.Error()})
+			failed = append(failed, resource.Name)
+			continue
+		}
+
+		applied, err := applyPlan(cmd, plan)
+		results = append(results, applied...)
+		if err != nil {
+			failed = append(failed, resource.Name)
+		}
+	}
+
+	if len(failed) > 0 && !ignoreErrors {
+		return results, fmt.Errorf("failed to import [%s] from [%s]", strings.Join(failed, ", "), dir)
+	}
+
+	return results, nil
+}
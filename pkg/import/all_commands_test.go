@@ -0,0 +1,77 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverResourcesKeepsDependencyOrder(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-all", nil)
+	defer remove()
+
+	for _, path := range []string{pkg.ServiceAccountsPath, pkg.TopicsPath, pkg.GroupsPath, pkg.ConnectionsFilePath} {
+		assert.Nil(t, os.MkdirAll(filepath.Join(dir, path), os.ModePerm))
+	}
+
+	resources, err := discoverResources(dir, "", landscapeResources("0s", 1))
+	assert.Nil(t, err)
+
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	assert.Equal(t, []string{"connections", "topics", "groups", "serviceaccounts"}, names)
+
+	_, err = discoverResources(filepath.Join(dir, pkg.TopicsPath), "", landscapeResources("0s", 1))
+	assert.Error(t, err)
+}
+
+func TestImportAllCommand(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-all", map[string]string{
+		pkg.GroupsPath + "/ops.yaml":               "name: ops\ndescription: operations\n",
+		pkg.ServiceAccountsPath + "/deployer.yaml": "name: deployer\ngroups:\n- ops\n",
+	})
+	defer remove()
+
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`[]`))
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte(`{"token": "secret"}`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportAllCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		// the snapshot of the live state, taken before the import.
+		"GET /api/v1/group",
+		"GET /api/v1/serviceaccount",
+		"GET /api/v1/group",
+		"POST /api/v1/group",
+		"GET /api/v1/serviceaccount",
+		"POST /api/v1/serviceaccount",
+	}, requests)
+
+	var results []applyResult
+	assert.Nil(t, json.Unmarshal([]byte(output), &results))
+	assert.Equal(t, []applyResult{
+		{Kind: "group", Name: "ops", Action: planCreate, Status: applySucceeded},
+		{Kind: "service account", Name: "deployer", Action: planCreate, Status: applySucceeded},
+	}, results)
+}
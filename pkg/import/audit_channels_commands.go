@@ -21,18 +21,25 @@ func NewImportAuditChannelsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, "audit-channels")
+			checkManifest(config.Client, path, pkg.AuditChannelsFilePath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.AuditChannelsFilePath)
 
-			err := importChannels(config.Client, cmd, path, "audit", pkg.AuditChannelsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planChannels(config.Client, cmd, opts, loadpath, "audit", pkg.AuditChannelsPath)
 			if err != nil {
 				return fmt.Errorf("error importing audit channels. [%v]", err)
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
@@ -0,0 +1,115 @@
+package imports
+
+import (
+	"fmt"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/broker"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+//NewImportBrokerConfigsCommand creates `import broker-configs` command
+func NewImportBrokerConfigsCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "broker-configs",
+		Short: "broker-configs",
+		Long: `Reconcile the dynamic configurations of the kafka cluster and brokers with the landscape,
+the configurations which are not in the landscape are reset to their static or default values.`,
+		Example:          `import broker-configs --dir my-landscape --dry-run`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checkManifest(config.Client, path, pkg.BrokerConfigsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.BrokerConfigsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planBrokerConfigs(config.Client, cmd, opts, loadpath)
+			if err != nil {
+				golog.Errorf("Failed to load broker configs. [%s]", err.Error())
+				return err
+			}
+			return runPlan(cmd, changes)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+
+	canPlan(cmd)
+	canOverlay(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
+
+func planBrokerConfigs(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+	golog.Infof("Loading broker configs from [%s]", loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []planChange
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
+		var desired api.BrokerConfigsPayload
+		if err := load(opts, importFilePath, &desired); err != nil {
+			if change, ok := invalidFile(cmd, "broker configs", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			golog.Errorf("Error loading file [%s]", importFilePath)
+			return nil, err
+		}
+
+		name := broker.ScopeName(desired.BrokerID)
+		live, err := broker.GetConfigs(client, desired.BrokerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the configs of %s. [%v]", name, err)
+		}
+
+		if desired.Configs == nil {
+			desired.Configs = api.DynamicBrokerConfigs{}
+		}
+
+		// the sensitive configs are compared only if they are in the landscape, their live values are unknown.
+		known := broker.KnownConfigs(live)
+		for key, value := range live {
+			if _, ok := desired.Configs[key]; ok && value == nil {
+				known[key] = value
+			}
+		}
+
+		change, err := opts.newChange("broker configs", name, importFilePath, comparableConfigs(known), comparableConfigs(desired.Configs), func() error {
+			return broker.ReconcileConfigs(client, desired.BrokerID, live, desired.Configs)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// comparableConfigs returns the configs with their values as strings, kafka returns them so whatever their types.
+func comparableConfigs(configs api.DynamicBrokerConfigs) api.DynamicBrokerConfigs {
+	comparable := make(api.DynamicBrokerConfigs, len(configs))
+	for key, value := range configs {
+		if value != nil {
+			value = fmt.Sprint(value)
+		}
+		comparable[key] = value
+	}
+	return comparable
+}
@@ -0,0 +1,57 @@
+package imports
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBrokerConfigs(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-broker-configs", map[string]string{
+		pkg.BrokerConfigsPath + "/broker-configs-cluster.yaml":  "configs:\n  log.cleaner.threads: 2\n  log.retention.ms: 3600000\n",
+		pkg.BrokerConfigsPath + "/broker-configs-broker-1.yaml": "brokerId: 1\nconfigs:\n  compression.type: lz4\n",
+	})
+	defer remove()
+
+	var updates []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := ioutil.ReadAll(r.Body)
+			updates = append(updates, r.Method+" "+r.URL.Path+" "+string(body))
+			return
+		}
+		if r.URL.Path == "/api/configs/brokers/1" {
+			w.Write([]byte(`{"compression.type": "lz4"}`))
+			return
+		}
+		w.Write([]byte(`{"log.cleaner.threads": "2", "compression.type": "gzip", "ssl.key.password": null}`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportBrokerConfigsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run")
+	assert.Nil(t, err)
+	assert.Empty(t, updates)
+
+	var plan importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &plan))
+	actions := make(map[string]planAction)
+	for _, change := range plan.Changes {
+		actions[change.Name] = change.Action
+	}
+	assert.Equal(t, map[string]planAction{"cluster": planUpdate, "broker-1": planNoop}, actions)
+
+	cmd = test.WithOutput(NewImportBrokerConfigsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`PUT /api/configs/brokers {"log.retention.ms":3600000}`,
+		`DELETE /api/configs/brokers ["compression.type"]`,
+	}, updates)
+}
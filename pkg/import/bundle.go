@@ -0,0 +1,88 @@
+package imports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bundleFlagKey    = "bundle"
+	verifyKeyFlagKey = "verify-key"
+)
+
+// canBundle registers the bundle flags to every subcommand with a `--dir`, added so far,
+// and makes it import the landscape of the `--bundle` instead, once it's verified.
+func canBundle(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		if sub.Flags().Lookup("dir") == nil || sub.RunE == nil {
+			continue
+		}
+
+		sub.Flags().String(bundleFlagKey, "", "Import the landscape of this bundle, written by 'export --bundle', instead of --dir, a bundle whose files do not match its manifest is refused")
+		sub.Flags().String(verifyKeyFlagKey, "", "Refuse the --bundle unless it's signed by the private key of this PEM encoded PKIX public key, e.g. created with 'openssl pkey -in key.pem -pubout -out key.pub.pem'")
+
+		runE := sub.RunE
+		sub.RunE = func(cmd *cobra.Command, args []string) error {
+			dir, cleanup, err := openBundle(cmd)
+			if err != nil {
+				golog.Errorf("Failed to open the landscape bundle. [%s]", err.Error())
+				return err
+			}
+			if dir == "" {
+				return runE(cmd, args)
+			}
+			defer cleanup()
+
+			if err = cmd.Flags().Set("dir", dir); err != nil {
+				return err
+			}
+			return runE(cmd, args)
+		}
+	}
+}
+
+// openBundle extracts the `--bundle` to a temporary directory and verifies it, the directory is removed by "cleanup".
+// It returns an empty directory when there is no bundle to import.
+func openBundle(cmd *cobra.Command) (dir string, cleanup func(), err error) {
+	bundle, _ := cmd.Flags().GetString(bundleFlagKey)
+	verifyKey, _ := cmd.Flags().GetString(verifyKeyFlagKey)
+
+	if bundle == "" {
+		if verifyKey != "" {
+			return "", nil, fmt.Errorf("--%s requires --%s", verifyKeyFlagKey, bundleFlagKey)
+		}
+		return "", nil, nil
+	}
+
+	if cmd.Flags().Changed("dir") {
+		return "", nil, fmt.Errorf("--dir and --%s cannot be used together", bundleFlagKey)
+	}
+
+	dir, err = ioutil.TempDir("", "lenses-landscape-bundle")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+
+	manifest, signed, err := utils.ExtractBundle(bundle, dir, verifyKey)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	switch {
+	case verifyKey != "":
+		golog.Infof("Verified the signature and the [%d] files of bundle [%s]", len(manifest.Files), bundle)
+	case signed:
+		golog.Warnf("Bundle [%s] is signed but its signature was not verified, set --%s to verify it", bundle, verifyKeyFlagKey)
+	default:
+		golog.Infof("Verified the [%d] files of bundle [%s]", len(manifest.Files), bundle)
+	}
+
+	return dir, cleanup, nil
+}
@@ -0,0 +1,154 @@
+package imports
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeKeyPair writes a PEM encoded ed25519 key pair to "dir" and returns the paths of its private and public keys.
+func writeKeyPair(t *testing.T, dir, name string) (string, string) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.Nil(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	assert.Nil(t, err)
+
+	privatePath, publicPath := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+".pub.pem")
+	assert.Nil(t, ioutil.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+	assert.Nil(t, ioutil.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644))
+	return privatePath, publicPath
+}
+
+// tamperBundle copies a bundle, replacing the contents of its "name" file.
+func tamperBundle(t *testing.T, src, dst, name, content string) {
+	in, err := os.Open(src)
+	assert.Nil(t, err)
+	defer in.Close()
+	gr, err := gzip.NewReader(in)
+	assert.Nil(t, err)
+
+	out, err := os.Create(dst)
+	assert.Nil(t, err)
+	defer out.Close()
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+
+		b, err := ioutil.ReadAll(tr)
+		assert.Nil(t, err)
+		if header.Name == name {
+			b = []byte(content)
+			header.Size = int64(len(b))
+		}
+
+		assert.Nil(t, tw.WriteHeader(header))
+		_, err = tw.Write(b)
+		assert.Nil(t, err)
+	}
+
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+}
+
+func TestImportBundle(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-bundle", nil)
+	defer remove()
+
+	landscape := filepath.Join(dir, "landscape")
+	test.WriteLandscapeFiles(t, landscape, map[string]string{
+		pkg.TopicsPath + "/topic-orders.yaml":   "name: orders\npartitions: 3\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-invoices.yaml": "name: invoices\npartitions: 1\nreplication: 1\n",
+	})
+
+	signKey, verifyKey := writeKeyPair(t, dir, "key")
+	_, otherVerifyKey := writeKeyPair(t, dir, "other")
+
+	bundle := filepath.Join(dir, "landscape.tar.gz")
+	manifest, err := utils.WriteBundle(bundle, landscape, []string{utils.ManifestFileName, pkg.TopicsPath}, signKey)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Files, 2)
+
+	unsigned := filepath.Join(dir, "unsigned.tar.gz")
+	_, err = utils.WriteBundle(unsigned, landscape, []string{pkg.TopicsPath}, "")
+	assert.Nil(t, err)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"topicName": "orders", "partitions": 1, "replication": 1, "config": []}]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	plan := func(args ...string) (map[string]planAction, error) {
+		cmd := test.WithOutput(NewImportGroupCommand(), "json")
+		output, err := test.ExecuteCommand(cmd, append([]string{"topics", "--dry-run"}, args...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		var p importPlan
+		assert.Nil(t, json.Unmarshal([]byte(output), &p))
+		actions := make(map[string]planAction)
+		for _, change := range p.Changes {
+			actions[change.Name] = change.Action
+		}
+		return actions, nil
+	}
+
+	expected := map[string]planAction{"orders": planUpdate, "invoices": planCreate}
+	actions, err := plan("--bundle", bundle, "--verify-key", verifyKey)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actions)
+
+	actions, err = plan("--bundle", unsigned)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actions)
+
+	// the signature must match the key and an unsigned bundle is refused when a key is set.
+	_, err = plan("--bundle", bundle, "--verify-key", otherVerifyKey)
+	assert.EqualError(t, err, "bundle ["+bundle+"] failed the signature verification. [the signature does not match the public key ["+otherVerifyKey+"]]")
+
+	_, err = plan("--bundle", unsigned, "--verify-key", verifyKey)
+	assert.EqualError(t, err, "bundle ["+unsigned+"] is not signed")
+
+	// a modified file is refused, signed or not.
+	tampered := filepath.Join(dir, "tampered.tar.gz")
+	tamperBundle(t, unsigned, tampered, pkg.TopicsPath+"/topic-orders.yaml", "name: orders\npartitions: 30\nreplication: 1\n")
+	_, err = plan("--bundle", tampered)
+	assert.EqualError(t, err, "bundle ["+tampered+"] was tampered with, ["+pkg.TopicsPath+"/topic-orders.yaml] was modified")
+
+	tamperBundle(t, bundle, tampered, utils.BundleManifestFileName, "{}")
+	_, err = plan("--bundle", tampered, "--verify-key", verifyKey)
+	assert.EqualError(t, err, "bundle ["+tampered+"] failed the signature verification. [the signature does not match the public key ["+verifyKey+"]]")
+
+	_, err = plan("--bundle", bundle, "--dir", landscape)
+	assert.EqualError(t, err, "--dir and --bundle cannot be used together")
+
+	// a rollback imports its snapshot, not a bundle.
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "rollback", "latest", "--bundle", bundle)
+	assert.EqualError(t, err, "unknown flag: --bundle")
+}
@@ -3,59 +3,74 @@ package imports
 import (
 	"encoding/json"
 	"fmt"
-	"reflect"
 
-	"github.com/lensesio/bite"
+	"github.com/kataras/golog"
 	"github.com/lensesio/lenses-go/pkg/api"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-func importChannels(client *api.Client, cmd *cobra.Command, loadpath, channelType, channelsPath string) error {
-	fmt.Fprintf(cmd.OutOrStdout(), "loading %s channels from [%s] directory\n", channelType, loadpath)
-
-	var targetChannels []api.ChannelPayload
-	files := utils.FindFiles(loadpath)
-	for _, file := range files {
-		var targetChannel api.ChannelPayload
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &targetChannel); err != nil {
-			return fmt.Errorf("error loading file [%s]", loadpath)
-		}
-		targetChannels = append(targetChannels, targetChannel)
-	}
+func planChannels(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath, channelType, channelsPath string) ([]planChange, error) {
+	golog.Infof("Loading %s channels from [%s] directory", channelType, loadpath)
 
 	channels, err := client.GetChannels(channelsPath, 1, 99999, "name", "asc", "", "")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var sourceChannels []api.ChannelPayload
-	for _, chann := range channels.Values {
-		var channForExport api.ChannelPayload
-		channΑsJSON, _ := json.Marshal(chann)
-		json.Unmarshal(channΑsJSON, &channForExport)
-		sourceChannels = append(sourceChannels, channForExport)
+	var changes []planChange
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
 	}
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
 
-	// Check for duplicates lacking server-side implementation
-	for _, targetChannel := range targetChannels {
-		found := false
-
-		for _, sourceChannel := range sourceChannels {
-			if reflect.DeepEqual(targetChannel, sourceChannel) {
-				found = true
+		var targetChannel api.ChannelPayload
+		if err := load(opts, importFilePath, &targetChannel); err != nil {
+			if change, ok := invalidFile(cmd, channelType+" channel", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
 			}
+			return nil, fmt.Errorf("error loading file [%s]", loadpath)
 		}
 
-		if found {
-			continue
+		var (
+			live  interface{}
+			apply = func() error {
+				if err := client.CreateChannel(targetChannel, channelsPath); err != nil {
+					return fmt.Errorf("error importing %s channel [%v]", channelType, targetChannel)
+				}
+				return nil
+			}
+		)
+
+		for _, chann := range channels.Values {
+			if chann.Name != targetChannel.Name {
+				continue
+			}
+
+			var sourceChannel api.ChannelPayload
+			channΑsJSON, _ := json.Marshal(chann)
+			json.Unmarshal(channΑsJSON, &sourceChannel)
+			live = sourceChannel
+
+			channelID := chann.ID
+			apply = func() error {
+				if err := client.UpdateChannel(targetChannel, channelsPath, channelID); err != nil {
+					return fmt.Errorf("error updating %s channel [%v]", channelType, targetChannel)
+				}
+				return nil
+			}
+			break
 		}
 
-		if err := client.CreateChannel(targetChannel, channelsPath); err != nil {
-			return fmt.Errorf("error importing %s channel [%v]", channelType, targetChannel)
+		change, err := opts.newChange(channelType+" channel", targetChannel.Name, importFilePath, live, targetChannel, apply)
+		if err != nil {
+			return nil, err
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "%s channel [%s] successfully imported\n", channelType, targetChannel.Name)
+
+		changes = append(changes, change)
 	}
 
-	return nil
+	return changes, nil
 }
@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,77 +23,118 @@ func NewImportConnectionsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.ConnectionsFilePath)
-			if err := loadConnections(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.ConnectionsFilePath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.ConnectionsFilePath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planConnections(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to import connections. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import from")
 
+	canPlan(cmd)
+	canOverlay(cmd)
 	bite.CanPrintJSON(cmd)
 	_ = bite.CanBeSilent(cmd)
 	return cmd
 }
 
-func loadConnections(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planConnections(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading connections from [%s]", loadpath)
 
 	currentConnections, err := client.GetConnections()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	files := utils.FindFiles(loadpath)
-	connTemplates, err := config.Client.GetConnectionTemplates()
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
+	connTemplates, err := client.GetConnectionTemplates()
 	if err != nil {
 		golog.Errorf("Error getting connection templates [%s]", err.Error())
-		return err
+		return nil, err
 	}
 
+	var changes []planChange
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
 		var connection api.Connection
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &connection); err != nil {
+		if err := load(opts, importFilePath, &connection); err != nil {
+			if change, ok := invalidFile(cmd, "connection", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
-		found := false
+		var (
+			live  interface{}
+			apply = func() error {
+				return client.CreateConnection(connection.Name, connection.TemplateName, "", connection.Configuration, connection.Tags)
+			}
+		)
+
 		for _, currentConn := range currentConnections {
-			if currentConn.Name == connection.Name {
-				found = true
-				golog.Infof("Updating connection [%s]", connection.Name)
-				if err := config.Client.UpdateConnection(currentConn.Name, connection.Name, "", connection.Configuration, connection.Tags); err != nil {
-					golog.Errorf("Error updating connection [%s]. [%s]", connection.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated connection [%s]", connection.Name)
+			if currentConn.Name != connection.Name {
 				continue
 			}
+
+			currentConnection, err := client.GetConnection(currentConn.Name)
+			if err != nil {
+				golog.Errorf("Error retrieving connection [%s]. [%s]", currentConn.Name, err.Error())
+				return nil, err
+			}
+
+			live = connectionState(currentConnection)
+			apply = func() error {
+				return client.UpdateConnection(connection.Name, connection.Name, "", connection.Configuration, connection.Tags)
+			}
+			break
 		}
-		if !found {
-			golog.Infof("Creating new connection [%s]", file.Name())
-			var connTemplateName string
+
+		if live == nil {
+			found := false
 			for _, connTemplate := range connTemplates {
 				if connTemplate.Name == connection.TemplateName {
-					connTemplateName = connTemplate.Name
+					found = true
 					break
 				}
 			}
-			if connTemplateName == "" {
-				golog.Errorf("Connection template %s for connection %s not found [%s]", connection.TemplateName, connection.Name, err.Error())
-				return err
-			}
-			if err := config.Client.CreateConnection(connection.Name, connTemplateName, "", connection.Configuration, connection.Tags); err != nil {
-				golog.Errorf("Error creating connection [%s] from [%s] [%s]", connection.Name, loadpath, err.Error())
-				return err
+			if !found {
+				return nil, fmt.Errorf("connection template [%s] for connection [%s] not found", connection.TemplateName, connection.Name)
 			}
-			golog.Infof("Created connection [%s]", connection.Name)
 		}
+
+		change, err := opts.newChange("connection", connection.Name, importFilePath, live, connectionState(connection), apply)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
 	}
 
-	return nil
+	return changes, nil
+}
+
+// connectionState returns the fields of a connection that can be imported,
+// the exported files contain the read-only fields as well.
+func connectionState(connection api.Connection) api.Connection {
+	return api.Connection{
+		Name:          connection.Name,
+		TemplateName:  connection.TemplateName,
+		Configuration: connection.Configuration,
+		Tags:          connection.Tags,
+	}
 }
@@ -2,10 +2,11 @@ package imports
 
 import (
 	"fmt"
-	"reflect"
+	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
@@ -15,6 +16,18 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	connectorClassKey = "connector.class"
+	sqlConnectorClass = "com.landoop.connect.SQL"
+
+	connectClusterFlagKey = "cluster-name"
+)
+
+// canConnectCluster registers the `--cluster-name` flag of the connectors in the Kafka Connect formats to an import command.
+func canConnectCluster(cmd *cobra.Command) {
+	cmd.Flags().String(connectClusterFlagKey, "", "Connect cluster of the connector files without one, the Kafka Connect .properties and {\"name\": .., \"config\": {..}} JSON files")
+}
+
 //NewImportConnectorsCommand create `import connectors`
 func NewImportConnectorsCommand() *cobra.Command {
 	var path string
@@ -22,60 +35,89 @@ func NewImportConnectorsCommand() *cobra.Command {
 	var retries int
 
 	cmd := &cobra.Command{
-		Use:              "connectors",
-		Short:            "connectors",
-		Example:          `import connectors --landscape /my-landscape --ignore-errors`,
+		Use:   "connectors",
+		Short: "connectors",
+		Long: `Create or update the connectors of the landscape. Besides the landscape files, the connector files can be
+Kafka Connect .properties files and {"name": .., "config": {..}} JSON files, their connectors are created in the --cluster-name cluster.`,
+		Example: `import connectors --landscape /my-landscape --ignore-errors
+import connectors --dir /my-landscape --cluster-name cluster1`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.ConnectorsPath)
-			if err := loadConnectors(config.Client, cmd, path, interval, retries); err != nil {
+			checkManifest(config.Client, path, pkg.ConnectorsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.ConnectorsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planConnectors(config.Client, cmd, opts, loadpath, interval, retries)
+			if err != nil {
 				return fmt.Errorf("failed to load connectors. [%s]", err.Error())
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 	cmd.Flags().StringVar(&interval, "interval", "0s", "Time between importing two connectors")
 	cmd.Flags().IntVar(&retries, "retries", 5, "Number of HTTP retries before exiting")
+	canConnectCluster(cmd)
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadConnectors(client *api.Client, cmd *cobra.Command, loadpath, interval string, retries int) error {
+func planConnectors(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath, interval string, retries int) ([]planChange, error) {
 	intervalDuration, err := time.ParseDuration(interval)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "loading connectors from [%s]\n", loadpath)
-	files := utils.FindFiles(loadpath)
+	golog.Infof("Loading connectors from [%s]", loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, file := range files {
-		var connector api.CreateUpdateConnectorPayload
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &connector); err != nil {
-			return err
-		}
+	clusterConnectors := make(map[string][]string)
 
-		connectors, err := client.GetConnectors(connector.ClusterName)
+	var changes []planChange
+	described := make(map[string]bool)
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
 
+		connector, err := loadConnector(cmd, opts, importFilePath)
 		if err != nil {
-			return err
+			if change, ok := invalidFile(cmd, "connector", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			return nil, err
 		}
 
-		existsOrUpdated := false
-		for _, name := range connectors {
-			if name == connector.Name {
-				var c api.Connector
+		connectors, ok := clusterConnectors[connector.ClusterName]
+		if !ok {
+			connectors, err = client.GetConnectors(connector.ClusterName)
+			if err != nil {
+				return nil, err
+			}
+			clusterConnectors[connector.ClusterName] = connectors
+		}
+
+		var (
+			live  interface{}
+			apply = func() error {
 				err := try.Do(func(attempt int) (bool, error) {
 					var err error
-					c, err = client.GetConnector(connector.ClusterName, connector.Name)
+					_, err = client.CreateConnector(connector.ClusterName, connector.Name, connector.Config)
 					if err != nil {
+						golog.Warnf("Failed to create connector [%s] [attempt num. %s]", connector.Name, strconv.Itoa(attempt))
 						time.Sleep(intervalDuration)
 					}
 					return attempt < retries, err
@@ -84,52 +126,129 @@ func loadConnectors(client *api.Client, cmd *cobra.Command, loadpath, interval s
 					return err
 				}
 
-				if !reflect.DeepEqual(c.Config, connector.Config) {
-					err := try.Do(func(attempt int) (bool, error) {
-						var err error
-						_, err = client.UpdateConnector(connector.ClusterName, connector.Name, connector.Config)
-						if err != nil {
-							fmt.Fprintf(cmd.OutOrStdout(), "failed to update connector '%s' [attempt num. %s]\n", connector.Name, strconv.Itoa(attempt))
-							time.Sleep(intervalDuration)
-						}
-						return attempt < retries, err
-					})
-					if err != nil {
-						return err
-					}
+				time.Sleep(intervalDuration)
+				return nil
+			}
+		)
 
-					fmt.Fprintf(cmd.OutOrStdout(), "updated connector config for cluster [%s], connector [%s]\n", connector.ClusterName, connector.Name)
+		for _, name := range connectors {
+			if name != connector.Name {
+				continue
+			}
 
-					if err != nil {
-						return err
-					}
+			var c api.Connector
+			err := try.Do(func(attempt int) (bool, error) {
+				var err error
+				c, err = client.GetConnector(connector.ClusterName, connector.Name)
+				if err != nil {
+					time.Sleep(intervalDuration)
 				}
+				return attempt < retries, err
+			})
+			if err != nil {
+				return nil, err
+			}
 
-				existsOrUpdated = true
-				break
+			live = c.Config
+			apply = func() error {
+				return try.Do(func(attempt int) (bool, error) {
+					var err error
+					_, err = client.UpdateConnector(connector.ClusterName, connector.Name, connector.Config)
+					if err != nil {
+						golog.Warnf("Failed to update connector [%s] [attempt num. %s]", connector.Name, strconv.Itoa(attempt))
+						time.Sleep(intervalDuration)
+					}
+					return attempt < retries, err
+				})
 			}
+			break
 		}
 
-		if existsOrUpdated {
-			continue
+		name := fmt.Sprintf("%s/%s", connector.ClusterName, connector.Name)
+		change, err := opts.newChange("connector", name, importFilePath, live, connector.Config, apply)
+		if err != nil {
+			return nil, err
 		}
 
-		err = try.Do(func(attempt int) (bool, error) {
-			var err error
-			_, err = client.CreateConnector(connector.ClusterName, connector.Name, connector.Config)
+		changes = append(changes, change)
+		described[name] = true
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	if !prune.enabled {
+		return changes, nil
+	}
+
+	clusters, err := client.GetConnectClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		connectors, ok := clusterConnectors[cluster.Name]
+		if !ok {
+			connectors, err = client.GetConnectors(cluster.Name)
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "failed to create connector '%s' [attempt num. %s]\n", connector.Name, strconv.Itoa(attempt))
-				time.Sleep(intervalDuration)
+				return nil, err
+			}
+		}
+
+		for _, connectorName := range connectors {
+			clusterName, connectorName := cluster.Name, connectorName
+			name := fmt.Sprintf("%s/%s", clusterName, connectorName)
+			if described[name] {
+				continue
 			}
-			return attempt < retries, err
-		})
+
+			// the connectors of SQL processors are managed by their processors.
+			c, err := client.GetConnector(clusterName, connectorName)
+			if err != nil {
+				return nil, err
+			}
+			if c.Config[connectorClassKey] == sqlConnectorClass {
+				continue
+			}
+
+			if change, ok := prune.deleteChange("connector", name, nil, func() error {
+				return client.DeleteConnector(clusterName, connectorName)
+			}); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// loadConnector loads a connector file, a landscape file or a Kafka Connect .properties or JSON file,
+// the connectors without cluster are created in the `--cluster-name` cluster.
+func loadConnector(cmd *cobra.Command, opts overlayOptions, path string) (api.CreateUpdateConnectorPayload, error) {
+	var connector api.CreateUpdateConnectorPayload
+	if filepath.Ext(path) == ".properties" {
+		text, err := opts.renderText(path)
+		if err != nil {
+			return connector, err
+		}
+
+		properties, err := utils.UnmarshalProperties([]byte(text))
 		if err != nil {
-			return err
+			return connector, err
 		}
+		connector.Config = api.ConnectorConfigFromProperties(properties)
+	} else if err := load(opts, path, &connector); err != nil {
+		return connector, err
+	}
+
+	if err := connector.ApplyAndValidateName(); err != nil {
+		return connector, err
+	}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "created  connector [%s] successfully!\n", connector.Name)
-		time.Sleep(intervalDuration)
+	if connector.ClusterName == "" {
+		connector.ClusterName, _ = cmd.Flags().GetString(connectClusterFlagKey)
+		if connector.ClusterName == "" {
+			return connector, fmt.Errorf("connector [%s] has no cluster, set --%s", connector.Name, connectClusterFlagKey)
+		}
 	}
 
-	return nil
+	return connector, nil
 }
@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportConnectorsConnectFormats(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-connectors", map[string]string{
+		pkg.ConnectorsPath + "/file-sink.properties": `# the orders sink
+name=file-sink
+connector.class=org.apache.kafka.connect.file.FileStreamSinkConnector
+topics = orders,\
+    refunds
+file: /tmp/ordersé.txt
+tasks.max=2
+`,
+		pkg.ConnectorsPath + "/file-source.json": `{"name": "file-source", "config": {"connector.class": "org.apache.kafka.connect.file.FileStreamSourceConnector",
+  "topic": "lines", "file": "/tmp/lines.txt"}}`,
+		pkg.ConnectorsPath + "/connector-prod-mirror.yaml": "clusterName: prod\nname: mirror\nconfig:\n  connector.class: MirrorSourceConnector\n",
+	})
+	defer remove()
+
+	var created []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			created = append(created, r.URL.Path)
+			w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["file-sink"]`))
+		case r.URL.Path == "/api/proxy-connect/prod/connectors":
+			w.Write([]byte(`[]`))
+		default:
+			w.Write([]byte(`{"name": "file-sink", "type": "sink", "tasks": [], "config": {"name": "file-sink",
+  "connector.class": "org.apache.kafka.connect.file.FileStreamSinkConnector", "topics": "orders,refunds", "file": "/tmp/ordersé.txt", "tasks.max": "1"}}`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportConnectorsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run")
+	assert.EqualError(t, err, "failed to load connectors. [connector [file-sink] has no cluster, set --cluster-name]")
+
+	cmd = test.WithOutput(NewImportConnectorsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--cluster-name", "dev")
+	assert.Nil(t, err)
+
+	var p importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &p))
+	changes := make(map[string]planChange)
+	for _, change := range p.Changes {
+		changes[change.Name] = change
+	}
+
+	assert.Len(t, changes, 3)
+	assert.Equal(t, planUpdate, changes["dev/file-sink"].Action)
+	assert.Equal(t, []fieldDiff{{Field: "tasks.max", Live: "1", Desired: "2"}}, changes["dev/file-sink"].Diff)
+	assert.Equal(t, planCreate, changes["dev/file-source"].Action)
+	// the cluster of a landscape file is kept.
+	assert.Equal(t, planCreate, changes["prod/mirror"].Action)
+
+	cmd = test.WithOutput(NewImportConnectorsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--no-snapshot", "--cluster-name", "dev")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"/api/proxy-connect/dev/connectors", "/api/proxy-connect/prod/connectors"}, created)
+}
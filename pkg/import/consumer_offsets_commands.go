@@ -0,0 +1,205 @@
+package imports
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+const (
+	offsetModeFlagKey = "offset-mode"
+
+	// offsetModeAbsolute restores the exported offsets as they are.
+	offsetModeAbsolute = "absolute"
+	// offsetModeTimestamp moves every partition to the first offset at or after the timestamp of the exported offset.
+	offsetModeTimestamp = "timestamp"
+	// offsetModeAuto translates by timestamp when the offsets of the target cluster differ from the exported ones:
+	// when the landscape was exported from another Lenses or the exported offset is out of the range of the partition.
+	offsetModeAuto = "auto"
+)
+
+//NewImportConsumerOffsetsCommand creates `import consumer-offsets` command
+func NewImportConsumerOffsetsCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "consumer-offsets",
+		Short: "consumer-offsets",
+		Long: `Restore the committed offsets of consumer groups, the groups must have no active members.
+The offsets are state rather than configuration, they are not part of "import all".`,
+		Example: `import consumer-offsets --dir my-landscape --dry-run
+import consumer-offsets --dir my-landscape --offset-mode auto`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode, _ := cmd.Flags().GetString(offsetModeFlagKey)
+			if mode != offsetModeAbsolute && mode != offsetModeTimestamp && mode != offsetModeAuto {
+				return fmt.Errorf("invalid --%s [%s], expected %s, %s or %s", offsetModeFlagKey, mode, offsetModeAbsolute, offsetModeTimestamp, offsetModeAuto)
+			}
+
+			checkManifest(config.Client, path, pkg.ConsumerOffsetsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.ConsumerOffsetsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planConsumerOffsets(config.Client, cmd, opts, loadpath)
+			if err != nil {
+				golog.Errorf("Failed to load consumer offsets. [%s]", err.Error())
+				return err
+			}
+			return runPlan(cmd, changes)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	cmd.Flags().String(offsetModeFlagKey, offsetModeAbsolute, "How the offsets are restored: absolute, timestamp to move to the timestamp of the exported offsets, "+
+		"or auto to translate by timestamp when the landscape comes from another Lenses or an offset is out of the range of its partition")
+
+	canPlan(cmd)
+	canOverlay(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
+
+func planConsumerOffsets(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+	golog.Infof("Loading consumer offsets from [%s]", loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := client.GetConsumerGroupsOffsets()
+	if err != nil {
+		golog.Errorf("Error retrieving consumer groups [%s]", err.Error())
+		return nil, err
+	}
+
+	mode, _ := cmd.Flags().GetString(offsetModeFlagKey)
+	var changes []planChange
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
+		var desired api.ConsumerGroupOffsetsPayload
+		if err := load(opts, importFilePath, &desired); err != nil {
+			if change, ok := invalidFile(cmd, "consumer offsets", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			golog.Errorf("Error loading file [%s]", importFilePath)
+			return nil, err
+		}
+
+		// the offsets are compared without their timestamps, which do not change where a group resumes.
+		var (
+			live  interface{}
+			state api.ConsumerGroupState
+		)
+		for _, group := range groups {
+			if group.ID == desired.Group {
+				live = comparableOffsets(group.ID, group.Offsets)
+				state = group.State
+				break
+			}
+		}
+
+		change, err := opts.newChange("consumer offsets", desired.Group, importFilePath, live, comparableOffsets(desired.Group, desired.Offsets), func() error {
+			// Kafka rejects the offsets of a group with active members.
+			if state == api.StateStable || state == api.StateRebalancing {
+				return fmt.Errorf("consumer group [%s] has active members, stop its consumers before restoring its offsets", desired.Group)
+			}
+			return restoreOffsets(client, desired, mode)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func comparableOffsets(group string, offsets []api.ConsumerPartitionOffset) api.ConsumerGroupOffsetsPayload {
+	comparable := make([]api.ConsumerPartitionOffset, 0, len(offsets))
+	for _, offset := range offsets {
+		comparable = append(comparable, api.ConsumerPartitionOffset{Topic: offset.Topic, Partition: offset.Partition, Offset: offset.Offset})
+	}
+
+	sort.Slice(comparable, func(i, j int) bool {
+		if comparable[i].Topic != comparable[j].Topic {
+			return comparable[i].Topic < comparable[j].Topic
+		}
+		return comparable[i].Partition < comparable[j].Partition
+	})
+
+	return api.ConsumerGroupOffsetsPayload{Group: group, Offsets: comparable}
+}
+
+// restoreOffsets moves the consumer group to the offsets of the landscape, partition by partition.
+func restoreOffsets(client *api.Client, desired api.ConsumerGroupOffsetsPayload, mode string) error {
+	ranges := make(map[string][]api.PartitionMessage)
+
+	for _, offset := range desired.Offsets {
+		partition := strconv.Itoa(offset.Partition)
+
+		translate := mode == offsetModeTimestamp
+		if mode == offsetModeAuto {
+			if desired.Host != "" && desired.Host != client.Config.Host {
+				translate = true
+			} else {
+				partitions, ok := ranges[offset.Topic]
+				if !ok {
+					topic, err := client.GetTopic(offset.Topic)
+					if err != nil {
+						return fmt.Errorf("failed to retrieve topic [%s]. [%v]", offset.Topic, err)
+					}
+					partitions = topic.MessagesPerPartition
+					ranges[offset.Topic] = partitions
+				}
+
+				translate = !inPartitionRange(partitions, offset)
+			}
+		}
+
+		if translate && offset.Timestamp <= 0 {
+			golog.Warnf("No timestamp exported for [%s] partition [%d] of consumer group [%s], restoring offset [%d]",
+				offset.Topic, offset.Partition, desired.Group, offset.Offset)
+			translate = false
+		}
+
+		var err error
+		if translate {
+			timestamp := time.Unix(0, offset.Timestamp*int64(time.Millisecond))
+			err = client.UpdateSingleTopicOffsetToTimestamp(desired.Group, offset.Topic, partition, timestamp)
+		} else {
+			err = client.UpdateSingleTopicAbsoluteOffset(desired.Group, offset.Topic, partition, offset.Offset)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to update the offset of [%s] partition [%d]. [%v]", offset.Topic, offset.Partition, err)
+		}
+	}
+
+	return nil
+}
+
+func inPartitionRange(partitions []api.PartitionMessage, offset api.ConsumerPartitionOffset) bool {
+	for _, p := range partitions {
+		if p.Partition == offset.Partition {
+			return offset.Offset >= p.Begin && offset.Offset <= p.End
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package imports
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const consumerOffsetsResp = `[
+  {"id": "payments", "state": "Empty", "offsets": [
+    {"topic": "orders", "partition": 0, "offset": 10},
+    {"topic": "orders", "partition": 1, "offset": 20}
+  ]},
+  {"id": "billing", "state": "Stable", "offsets": [
+    {"topic": "orders", "partition": 0, "offset": 5}
+  ]}
+]`
+
+const ordersTopicResp = `{"topicName": "orders", "partitions": 2, "replication": 1,
+  "messagesPerPartition": [{"partition": 0, "messages": 100, "begin": 0, "end": 100}, {"partition": 1, "messages": 50, "begin": 0, "end": 50}]}`
+
+func TestImportConsumerOffsets(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-consumer-offsets", nil)
+	defer remove()
+
+	var updates []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(r.Body)
+			updates = append(updates, r.URL.Path+" "+string(body))
+			return
+		}
+		if r.URL.Path == "/api/topics/orders" {
+			w.Write([]byte(ordersTopicResp))
+			return
+		}
+		w.Write([]byte(consumerOffsetsResp))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	landscape := func(host string) {
+		test.WriteLandscapeFiles(t, dir, map[string]string{
+			pkg.ConsumerOffsetsPath + "/consumer-offsets-payments.yaml": `group: payments
+host: ` + host + `
+offsets:
+- topic: orders
+  partition: 0
+  offset: 42
+  timestamp: 1577836800000
+- topic: orders
+  partition: 1
+  offset: 70
+  timestamp: 1577836860000
+`,
+		})
+	}
+
+	run := func(args ...string) []string {
+		updates = nil
+		cmd := test.WithOutput(NewImportConsumerOffsetsCommand(), "json")
+		_, err := test.ExecuteCommand(cmd, append([]string{"--dir", dir}, args...)...)
+		assert.Nil(t, err)
+		sort.Strings(updates)
+		return updates
+	}
+
+	landscape(client.Config.Host)
+	assert.Empty(t, run("--dry-run"))
+
+	assert.Equal(t, []string{
+		`/api/consumers/payments/offsets/topics/orders/partitions/0 {"type":"absolute","offset":42}`,
+		`/api/consumers/payments/offsets/topics/orders/partitions/1 {"type":"absolute","offset":70}`,
+	}, run())
+
+	// the offset of the partition 1 is past its end, it is translated by timestamp.
+	assert.Equal(t, []string{
+		`/api/consumers/payments/offsets/topics/orders/partitions/0 {"type":"absolute","offset":42}`,
+		`/api/consumers/payments/offsets/topics/orders/partitions/1 {"type":"timestamp","target":"2020-01-01T00:01:00Z"}`,
+	}, run("--offset-mode", "auto"))
+
+	// offsets exported from another Lenses are all translated by timestamp.
+	landscape("https://another-lenses:9991")
+	assert.Equal(t, []string{
+		`/api/consumers/payments/offsets/topics/orders/partitions/0 {"type":"timestamp","target":"2020-01-01T00:00:00Z"}`,
+		`/api/consumers/payments/offsets/topics/orders/partitions/1 {"type":"timestamp","target":"2020-01-01T00:01:00Z"}`,
+	}, run("--offset-mode", "auto"))
+
+	// the offset 0 is restored as it is.
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.ConsumerOffsetsPath + "/consumer-offsets-payments.yaml": "group: payments\noffsets:\n- topic: orders\n  partition: 0\n  offset: 0\n",
+	})
+	assert.Equal(t, []string{
+		`/api/consumers/payments/offsets/topics/orders/partitions/0 {"type":"absolute","offset":0}`,
+	}, run())
+
+	// the offsets of a group with active members are not restored.
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.ConsumerOffsetsPath + "/consumer-offsets-billing.yaml": "group: billing\noffsets:\n- topic: orders\n  partition: 0\n  offset: 1\n",
+	})
+	updates = nil
+	cmd := test.WithOutput(NewImportConsumerOffsetsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir)
+	assert.NotNil(t, err)
+	assert.Empty(t, updates)
+
+	cmd = NewImportConsumerOffsetsCommand()
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--offset-mode", "latest")
+	assert.EqualError(t, err, "invalid --offset-mode [latest], expected absolute, timestamp or auto")
+}
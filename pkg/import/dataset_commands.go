@@ -0,0 +1,114 @@
+package imports
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/dataset"
+	"github.com/spf13/cobra"
+)
+
+//NewImportDatasetsCommand creates `import datasets` command
+func NewImportDatasetsCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "datasets",
+		Short: "datasets",
+		Long: `Update the descriptions, tags and topic metadata of the datasets from the landscape,
+the datasets must exist, e.g. their topics imported first.`,
+		Example:          `import datasets --dir my-landscape --dry-run`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checkManifest(config.Client, path, pkg.DatasetsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.DatasetsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planDatasets(config.Client, cmd, opts, loadpath)
+			if err != nil {
+				golog.Errorf("Failed to load datasets. [%s]", err.Error())
+				return err
+			}
+			return runPlan(cmd, changes)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+
+	canPlan(cmd)
+	canOverlay(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
+
+func planDatasets(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+	golog.Infof("Loading datasets from [%s]", loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue, err := dataset.Catalogue(client)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []planChange
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
+		var payload api.DatasetsPayload
+		if err := load(opts, importFilePath, &payload); err != nil {
+			if change, ok := invalidFile(cmd, "dataset", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			golog.Errorf("Error loading file [%s]", importFilePath)
+			return nil, err
+		}
+
+		for _, desired := range payload.Datasets {
+			desired := desired
+			desired.Tags = append([]string{}, desired.Tags...)
+			sort.Strings(desired.Tags)
+
+			// the datasets without catalogue information are compared to an empty one, the dataset itself exists.
+			live := &api.DatasetPayload{Name: desired.Name}
+			for _, d := range catalogue[payload.Connection] {
+				if d.Name == desired.Name {
+					d := d
+					live = &d
+					break
+				}
+			}
+
+			// an omitted description is not managed by the landscape.
+			compared := *live
+			if desired.Description == nil {
+				compared.Description = nil
+			}
+
+			connection := payload.Connection
+			change, err := opts.newChange("dataset", connection+"/"+desired.Name, importFilePath, compared, desired, func() error {
+				return dataset.UpdateCatalogue(client, connection, live, desired)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,68 @@
+package imports
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const datasetsResp = `{"datasets": {"values": [
+  {"name": "orders", "connectionName": "kafka", "description": "Orders", "tags": [{"name": "sales"}]},
+  {"name": "payments", "connectionName": "kafka", "tags": []},
+  {"name": "customers", "connectionName": "postgres", "description": "Customers", "tags": [{"name": "pii"}]}
+], "pagesAmount": 1}}`
+
+func TestImportDatasets(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-datasets", map[string]string{
+		pkg.DatasetsPath + "/datasets-kafka.yaml": `connection: kafka
+datasets:
+- name: orders
+  tags: [sales]
+  keyType: STRING
+  valueType: AVRO
+- name: payments
+  description: Card payments
+  tags: [finance, pii]
+`,
+		pkg.DatasetsPath + "/datasets-postgres.yaml": `connection: postgres
+datasets:
+- name: customers
+  description: ""
+  tags: [pii]
+`,
+	})
+	defer remove()
+
+	var updates []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodGet:
+			body, _ := ioutil.ReadAll(r.Body)
+			updates = append(updates, r.Method+" "+r.URL.Path+" "+string(body))
+		case r.URL.Path == "/api/v1/datasets":
+			w.Write([]byte(datasetsResp))
+		default:
+			w.Write([]byte(`[{"topicName": "orders", "keyType": "STRING", "valueType": "JSON"}]`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportDatasetsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Nil(t, err)
+
+	// the omitted description of "orders" is left as it is, the empty one of "customers" is removed.
+	sort.Strings(updates)
+	assert.Equal(t, []string{
+		`POST /api/v1/metadata/topics {"topicName":"orders","keyType":"STRING","valueType":"AVRO"}`,
+		`PUT /api/v1/datasets/kafka/payments/description {"description":"Card payments"}`,
+		`PUT /api/v1/datasets/kafka/payments/tags {"tags":[{"name":"finance"},{"name":"pii"}]}`,
+		`PUT /api/v1/datasets/postgres/customers/description {}`,
+	}, updates)
+}
@@ -0,0 +1,220 @@
+package imports
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// landscapeDocument is a document of a multi-document landscape file, "source" locates it, e.g. "landscape.yaml#3".
+type landscapeDocument struct {
+	kind   string
+	name   string
+	source string
+	raw    []byte
+}
+
+// landscapeDocuments are the documents found by landscapeFiles, keyed by their path in the directory layout.
+var landscapeDocuments = struct {
+	sync.RWMutex
+	byPath map[string]landscapeDocument
+}{byPath: make(map[string]landscapeDocument)}
+
+// documentInfo is the file of a landscape document in the directory layout.
+type documentInfo struct {
+	name string
+	size int64
+}
+
+func (d documentInfo) Name() string       { return d.name }
+func (d documentInfo) Size() int64        { return d.size }
+func (d documentInfo) Mode() os.FileMode  { return 0444 }
+func (d documentInfo) ModTime() time.Time { return time.Time{} }
+func (d documentInfo) IsDir() bool        { return false }
+func (d documentInfo) Sys() interface{}   { return nil }
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// documentFiles returns the multi-document files of a landscape: the landscape itself if it is a file,
+// or the YAML files at the root of the landscape directory.
+func documentFiles(root string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{root}, nil
+	}
+
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(root, entry.Name()))
+		}
+	}
+
+	return files, nil
+}
+
+// documentHeaders are the headers of a landscape document.
+type documentHeaders struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Kind       string                 `yaml:"kind"`
+	Metadata   utils.DocumentMetadata `yaml:"metadata"`
+}
+
+// readDocuments returns the documents of the multi-document files of a landscape, by resource directory.
+// The YAML files without documents, e.g. a values file, are not landscape files and are skipped.
+func readDocuments(root string) (map[string][]landscapeDocument, error) {
+	files, err := documentFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string][]landscapeDocument)
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		raws := utils.SplitDocuments(b)
+		for i, raw := range raws {
+			source := fmt.Sprintf("%s#%d", file, i+1)
+
+			var headers documentHeaders
+			if err = yaml.Unmarshal(raw, &headers); err != nil {
+				if i == 0 && len(raws) == 1 {
+					golog.Debugf("Skipping [%s], it is not a landscape file. [%s]", file, err.Error())
+					break
+				}
+				return nil, fmt.Errorf("invalid document [%s]. [%v]", source, err)
+			}
+
+			if headers.APIVersion == "" && headers.Kind == "" {
+				if i == 0 {
+					golog.Debugf("Skipping [%s], it has no landscape documents", file)
+					break
+				}
+				return nil, fmt.Errorf("document [%s] has no kind and apiVersion", source)
+			}
+
+			if headers.APIVersion != utils.DocumentAPIVersion {
+				return nil, fmt.Errorf("document [%s] has the unsupported apiVersion [%s], expected [%s]", source, headers.APIVersion, utils.DocumentAPIVersion)
+			}
+
+			path, ok := utils.PathOfKind(headers.Kind)
+			if !ok {
+				return nil, fmt.Errorf("document [%s] has the unknown kind [%s]", source, headers.Kind)
+			}
+
+			name := headers.Metadata.Name
+			if name == "" {
+				name = fmt.Sprintf("%s-%d", strings.ToLower(headers.Kind), i+1)
+			}
+
+			for _, d := range byPath[path] {
+				if d.name == name {
+					return nil, fmt.Errorf("documents [%s] and [%s] are both the %s [%s]", d.source, source, headers.Kind, name)
+				}
+			}
+
+			byPath[path] = append(byPath[path], landscapeDocument{kind: headers.Kind, name: name, source: source, raw: raw})
+		}
+	}
+
+	return byPath, nil
+}
+
+// registerDocuments registers the documents of a resource directory of the landscape, loaded from "loadpath",
+// and returns their files in the directory layout.
+func registerDocuments(root, loadpath string, existing map[string]os.FileInfo) ([]os.FileInfo, error) {
+	rel, err := filepath.Rel(root, loadpath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, nil
+	}
+
+	byPath, err := readDocuments(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []os.FileInfo
+	landscapeDocuments.Lock()
+	defer landscapeDocuments.Unlock()
+	for _, d := range byPath[filepath.ToSlash(rel)] {
+		info := documentInfo{name: d.name + ".yaml", size: int64(len(d.raw))}
+		if _, ok := existing[info.name]; ok {
+			return nil, fmt.Errorf("the %s [%s] of document [%s] is also the file [%s]", d.kind, d.name, d.source, filepath.Join(loadpath, info.name))
+		}
+
+		landscapeDocuments.byPath[filepath.Clean(filepath.Join(loadpath, info.name))] = d
+		files = append(files, info)
+	}
+
+	return files, nil
+}
+
+// readLandscapeFile returns the contents of a landscape file, or of the document registered at its path.
+func readLandscapeFile(file string) ([]byte, error) {
+	landscapeDocuments.RLock()
+	d, ok := landscapeDocuments.byPath[filepath.Clean(file)]
+	landscapeDocuments.RUnlock()
+	if ok {
+		return d.raw, nil
+	}
+
+	return ioutil.ReadFile(file)
+}
+
+// unwrapDocument returns the spec of a landscape file which is a document, after checking its kind belongs to the resource directory
+// of the file, and any other landscape file as it is.
+func (o overlayOptions) unwrapDocument(file string, b []byte) ([]byte, error) {
+	var doc interface{}
+	if err := unmarshalByExt(file, b, &doc); err != nil {
+		// not decodable as a whole, e.g. a JSON file in a YAML directory, the decoding of the file reports it.
+		return b, nil
+	}
+
+	apiVersion, kind, ok := utils.DocumentHeaders(doc)
+	if !ok {
+		return b, nil
+	}
+
+	if apiVersion != utils.DocumentAPIVersion {
+		return nil, fmt.Errorf("[%s] has the unsupported apiVersion [%s], expected [%s]", file, apiVersion, utils.DocumentAPIVersion)
+	}
+
+	if rel, err := filepath.Rel(o.root, filepath.Dir(file)); err == nil {
+		if expected, ok := utils.KindOfPath(filepath.ToSlash(rel)); ok && expected != kind {
+			return nil, fmt.Errorf("[%s] is a %s document but the [%s] directory holds %s documents", file, kind, filepath.ToSlash(rel), expected)
+		}
+	}
+
+	var spec interface{}
+	switch m := doc.(type) {
+	case map[interface{}]interface{}:
+		spec = m["spec"]
+	case map[string]interface{}:
+		spec = m["spec"]
+	}
+
+	return marshalByExt(file, spec)
+}
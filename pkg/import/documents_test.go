@@ -0,0 +1,93 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const landscapeDocumentsFile = `# the topics of the orders service
+apiVersion: landscape.lenses.io/v1
+kind: Topic
+metadata:
+  name: topic-orders
+spec:
+  name: orders
+  partitions: ${partitions}
+  replication: 1
+---
+apiVersion: landscape.lenses.io/v1
+kind: Topic
+spec:
+  name: invoices
+  partitions: 1
+  replication: 1
+`
+
+func TestImportDocuments(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-documents", map[string]string{
+		"landscape.yaml": landscapeDocumentsFile,
+		"values.yaml":    "partitions: 3\n",
+	})
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"topicName": "orders", "partitions": 1, "replication": 1, "config": []}]`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	plan := func(landscape string) (map[string]planAction, error) {
+		cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+		output, err := test.ExecuteCommand(cmd, "--dir", landscape, "--dry-run", "--values", filepath.Join(dir, "values.yaml"))
+		if err != nil {
+			return nil, err
+		}
+
+		var p importPlan
+		assert.Nil(t, json.Unmarshal([]byte(output), &p))
+		actions := make(map[string]planAction)
+		for _, change := range p.Changes {
+			actions[change.Name] = change.Action
+		}
+		return actions, nil
+	}
+
+	// the documents of the files at the root of a landscape directory, or of a landscape file.
+	expected := map[string]planAction{"orders": planUpdate, "invoices": planCreate}
+	actions, err := plan(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actions)
+
+	actions, err = plan(filepath.Join(dir, "landscape.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actions)
+
+	// a document in the directory layout is checked against its directory.
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.TopicsPath + "/topic-payments.yaml": "apiVersion: landscape.lenses.io/v1\nkind: Topic\nspec:\n  name: payments\n  partitions: 1\n  replication: 1\n",
+	})
+	actions, err = plan(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]planAction{"orders": planUpdate, "invoices": planCreate, "payments": planCreate}, actions)
+
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.TopicsPath + "/quotas.yaml": "apiVersion: landscape.lenses.io/v1\nkind: QuotaList\nspec: []\n",
+	})
+	_, err = plan(dir)
+	assert.EqualError(t, err, "["+filepath.Join(dir, pkg.TopicsPath, "quotas.yaml")+"] is a QuotaList document but the [kafka/topics] directory holds Topic documents")
+	assert.Nil(t, os.Remove(filepath.Join(dir, pkg.TopicsPath, "quotas.yaml")))
+
+	// a document and a file of the directory layout cannot be the same resource.
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.TopicsPath + "/topic-orders.yaml": "name: orders\npartitions: 1\nreplication: 1\n",
+	})
+	_, err = plan(dir)
+	assert.EqualError(t, err, "the Topic [topic-orders] of document ["+filepath.Join(dir, "landscape.yaml")+"#1] is also the file ["+filepath.Join(dir, pkg.TopicsPath, "topic-orders.yaml")+"]")
+}
@@ -0,0 +1,208 @@
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// ErrDriftDetected is returned by `landscape diff` when the live state differs from the landscape.
+var ErrDriftDetected = errors.New("the live state drifted from the landscape")
+
+const (
+	// DriftChanged is the status of a resource whose live state differs from its landscape file.
+	DriftChanged = "changed"
+	// DriftMissing is the status of a resource described in the landscape which does not exist.
+	DriftMissing = "missing"
+	// DriftUnmanaged is the status of a live resource which is not described in the landscape,
+	// reported only when `--prune` is set.
+	DriftUnmanaged = "unmanaged"
+)
+
+// ResourceDrift is the difference between the landscape and the live state of a single resource,
+// "Diff" is a unified diff of their normalised forms, from the live state to the landscape.
+type ResourceDrift struct {
+	Resource string `json:"resource" yaml:"resource"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	File     string `json:"file,omitempty" yaml:"file,omitempty"`
+	Diff     string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// CanDiff registers the flags of the import planners that are meaningful to a drift check.
+func CanDiff(cmd *cobra.Command) {
+	canOverlay(cmd)
+	cmd.Flags().Bool(pruneFlagKey, false, "Report the live resources that are not described in the landscape, as `import --prune` would delete them")
+	cmd.Flags().StringSlice(pruneProtectFlagKey, nil, "Glob patterns of resource names that are never reported as unmanaged, e.g. --prune-protect='_*,audit-*'")
+	cmd.Flags().String(pruneOwnerFlagKey, "", "Report as unmanaged only the resources owned by this label, see `import --prune-owner`")
+}
+
+// DiffLandscape loads every resource directory of a landscape with the import planners
+// and returns the resources whose live state differs from it.
+func DiffLandscape(client *api.Client, cmd *cobra.Command, dir string) ([]ResourceDrift, error) {
+	opts, err := overlayOptionsFrom(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := discoverResources(dir, opts.overlay, landscapeResources("0s", 1))
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []ResourceDrift{}
+	for _, resource := range resources {
+		changes, err := resource.plan(client, cmd, opts, filepath.Join(dir, resource.Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s. [%v]", resource.Name, err)
+		}
+
+		for _, change := range changes {
+			drift, drifted, err := changeDrift(resource.Name, change)
+			if err != nil {
+				return nil, err
+			}
+
+			if drifted {
+				drifts = append(drifts, drift)
+			}
+		}
+	}
+
+	return drifts, nil
+}
+
+func changeDrift(resource string, change planChange) (ResourceDrift, bool, error) {
+	drift := ResourceDrift{Resource: resource, Kind: change.Kind, Name: change.Name, File: change.File}
+
+	switch change.Action {
+	case planCreate:
+		drift.Status = DriftMissing
+	case planUpdate:
+		drift.Status = DriftChanged
+	case planDelete:
+		drift.Status = DriftUnmanaged
+		return drift, true, nil
+	default:
+		return drift, false, nil
+	}
+
+	var live []string
+	if drift.Status == DriftChanged {
+		var err error
+		if live, err = normalizedLines(change.live, change.secrets); err != nil {
+			return drift, false, fmt.Errorf("unable to normalise the live state of %s [%s]. [%v]", change.Kind, change.Name, err)
+		}
+	}
+
+	desired, err := normalizedLines(change.desired, change.secrets)
+	if err != nil {
+		return drift, false, fmt.Errorf("unable to normalise %s [%s] of [%s]. [%v]", change.Kind, change.Name, change.File, err)
+	}
+
+	drift.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        live,
+		B:        desired,
+		FromFile: fmt.Sprintf("live/%s/%s", resource, change.Name),
+		ToFile:   filepath.ToSlash(change.File),
+		Context:  3,
+	})
+	if err != nil {
+		return drift, false, err
+	}
+
+	// the planners compare ordered lists, a difference only in the order of the values is not a drift.
+	if drift.Diff == "" {
+		return drift, false, nil
+	}
+
+	return drift, true, nil
+}
+
+// normalizedLines returns the normalised form of a resource as one sorted `field: value` line per value,
+// without its empty values and its resolved secrets.
+func normalizedLines(v interface{}, secrets *resolvedSecrets) ([]string, error) {
+	generic, err := asGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	flattenValue("", secrets.redact(generic), &lines)
+	return lines, nil
+}
+
+func flattenValue(field string, v interface{}, lines *[]string) {
+	switch value := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			name := k
+			if field != "" {
+				name = field + "." + k
+			}
+			flattenValue(name, value[k], lines)
+		}
+	case []interface{}:
+		items := value
+		if allScalars(value) {
+			items = make([]interface{}, len(value))
+			copy(items, value)
+			sort.Slice(items, func(i, j int) bool { return scalarString(items[i]) < scalarString(items[j]) })
+		}
+
+		for i, item := range items {
+			flattenValue(fmt.Sprintf("%s[%d]", field, i), item, lines)
+		}
+	default:
+		*lines = append(*lines, fmt.Sprintf("%s: %s\n", field, scalarString(value)))
+	}
+}
+
+func allScalars(values []interface{}) bool {
+	for _, v := range values {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+
+	return true
+}
+
+// WriteDrifts writes the human readable form of the drifts.
+func WriteDrifts(w io.Writer, drifts []ResourceDrift) {
+	counts := make(map[string]int)
+	for _, drift := range drifts {
+		counts[drift.Status]++
+
+		switch drift.Status {
+		case DriftMissing:
+			fmt.Fprintf(w, "+ %s [%s] is missing (%s)\n", drift.Kind, drift.Name, drift.File)
+		case DriftUnmanaged:
+			fmt.Fprintf(w, "- %s [%s] is not described in the landscape\n", drift.Kind, drift.Name)
+		default:
+			fmt.Fprintf(w, "~ %s [%s] has changed (%s)\n", drift.Kind, drift.Name, drift.File)
+		}
+
+		if drift.Diff != "" {
+			fmt.Fprint(w, strings.TrimSuffix(drift.Diff, "\n")+"\n")
+		}
+	}
+
+	fmt.Fprintf(w, "Drift: %d changed, %d missing, %d unmanaged.\n", counts[DriftChanged], counts[DriftMissing], counts[DriftUnmanaged])
+}
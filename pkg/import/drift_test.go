@@ -0,0 +1,71 @@
+package imports
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizedLines(t *testing.T) {
+	live, err := normalizedLines(api.CreateTopicPayload{
+		TopicName:  "orders",
+		Partitions: 3,
+		Configs:    api.KV{"retention.ms": "1000000", "cleanup.policy": "delete"},
+	}, nil)
+	assert.Nil(t, err)
+
+	desired, err := normalizedLines(map[string]interface{}{
+		"configs":     map[string]interface{}{"cleanup.policy": "delete", "retention.ms": 1000000},
+		"partitions":  "3",
+		"replication": 0,
+		"topicName":   "orders",
+		"description": "",
+		"tags":        []string{},
+	}, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"configs.cleanup.policy: delete\n",
+		"configs.retention.ms: 1000000\n",
+		"description: \n",
+		"partitions: 3\n",
+		"replication: 0\n",
+		"topicName: orders\n",
+	}, desired)
+	assert.Equal(t, desired, live)
+
+	groups, err := normalizedLines(api.ServiceAccount{Name: "pam", Groups: []string{"b", "a"}}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"groups[0]: a\n", "groups[1]: b\n", "name: pam\n"}, groups)
+}
+
+func TestDiffLandscape(t *testing.T) {
+	dir, remove := writePlanServiceAccounts(t)
+	defer remove()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(planServiceAccountsResp))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := &cobra.Command{}
+	CanDiff(cmd)
+
+	drifts, err := DiffLandscape(client, cmd, dir)
+	assert.Nil(t, err)
+	assert.Len(t, drifts, 2)
+
+	assert.Equal(t, "sam", drifts[0].Name)
+	assert.Equal(t, DriftChanged, drifts[0].Status)
+	assert.Contains(t, drifts[0].Diff, "--- live/serviceaccounts/sam\n")
+	assert.Contains(t, drifts[0].Diff, "-groups[0]: bar\n+groups[0]: foo\n")
+
+	assert.Equal(t, "tim", drifts[1].Name)
+	assert.Equal(t, DriftMissing, drifts[1].Status)
+	assert.Contains(t, drifts[1].Diff, "+name: tim\n")
+}
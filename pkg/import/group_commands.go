@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,72 +23,113 @@ func NewImportGroupsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.GroupsPath)
-			if err := loadGroups(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.GroupsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.GroupsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planGroups(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load user groups. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadGroups(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planGroups(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading user groups from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	currentGroups, err := client.GetGroups()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
+
+	var changes []planChange
+	described := make(map[string]bool)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
 
 		var group api.Group
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &group); err != nil {
+		if err := load(opts, importFilePath, &group); err != nil {
+			if change, ok := invalidFile(cmd, "group", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
-		found := false
+		payload := groupState(group)
+
+		var (
+			live  interface{}
+			apply = func() error {
+				return client.CreateGroup(&group)
+			}
+		)
+
 		for _, g := range currentGroups {
 			if g.Name == group.Name {
-				found = true
-				payload := &api.Group{
-					Name:                       group.Name,
-					Description:                group.Description,
-					Namespaces:                 group.Namespaces,
-					ScopedPermissions:          group.ScopedPermissions,
-					AdminPermissions:           group.AdminPermissions,
-					ConnectClustersPermissions: group.ConnectClustersPermissions,
+				live = groupState(g)
+				apply = func() error {
+					return client.UpdateGroup(&payload)
 				}
-
-				if err := config.Client.UpdateGroup(payload); err != nil {
-					golog.Errorf("Error updating user group [%s]. [%s]", group.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated group [%s]", group.Name)
+				break
 			}
 		}
 
-		if found {
-			continue
+		change, err := opts.newChange("group", group.Name, importFilePath, live, payload, apply)
+		if err != nil {
+			return nil, err
 		}
 
-		if err := client.CreateGroup(&group); err != nil {
-			golog.Errorf("Error creating user group [%s] from [%s] [%s]", group.Name, loadpath, err.Error())
-			return err
+		changes = append(changes, change)
+		described[group.Name] = true
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	for _, g := range currentGroups {
+		if described[g.Name] {
+			continue
 		}
-		golog.Infof("Created user group [%s]", group.Name)
 
+		name := g.Name
+		if change, ok := prune.deleteChange("group", name, nil, func() error {
+			return client.DeleteGroup(name)
+		}); ok {
+			changes = append(changes, change)
+		}
 	}
 
-	return nil
+	return changes, nil
+}
+
+// groupState returns the fields of a group that can be imported, the accounts counters are read-only.
+func groupState(group api.Group) api.Group {
+	return api.Group{
+		Name:                       group.Name,
+		Description:                group.Description,
+		Namespaces:                 group.Namespaces,
+		ScopedPermissions:          group.ScopedPermissions,
+		AdminPermissions:           group.AdminPermissions,
+		ConnectClustersPermissions: group.ConnectClustersPermissions,
+	}
 }
@@ -1,7 +1,6 @@
 package imports
 
 import (
-	"github.com/lensesio/bite"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +10,7 @@ func NewImportGroupCommand() *cobra.Command {
 		Use:   "import",
 		Short: "import a landscape",
 		Example: `
+import all --dir my-landscape
 import acls --landscape my-acls-dir
 import alert-settings --landscape my-acls-dir
 import connectors --landscape my-acls-dir
@@ -21,11 +21,19 @@ import schemas --landscape my-acls-dir
 import topics --landscape my-acls-dir
 import policies --landscape my-acls-dir
 import groups --dir groups
-import serviceaccounts --dir serviceaccounts`,
+import serviceaccounts --dir serviceaccounts
+import broker-configs --dir my-landscape --dry-run
+import datasets --dir my-landscape
+import consumer-offsets --dir my-landscape --offset-mode auto
+import topics --dir my-landscape --dry-run --detailed-exitcode
+import all --bundle landscape.tar.gz --verify-key key.pub.pem --dry-run
+import rollback 20201201-102030 --yes
+import topics --dir my-landscape --prune --prune-protect='audit-*' --prune-owner=platform --yes`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 	}
 
+	cmd.AddCommand(NewImportAllCommand())
 	cmd.AddCommand(NewImportAclsCommand())
 	cmd.AddCommand(NewImportAlertSettingsCommand())
 	cmd.AddCommand(NewImportConnectionsCommand())
@@ -39,10 +47,41 @@ import serviceaccounts --dir serviceaccounts`,
 	cmd.AddCommand(NewImportServiceAccountsCommand())
 	cmd.AddCommand(NewImportAlertChannelsCommand())
 	cmd.AddCommand(NewImportAuditChannelsCommand())
+	cmd.AddCommand(NewImportBrokerConfigsCommand())
+	cmd.AddCommand(NewImportDatasetsCommand())
+	cmd.AddCommand(NewImportConsumerOffsetsCommand())
+	canBundle(cmd)
+	cmd.AddCommand(NewImportRollbackCommand())
 
 	return cmd
 }
 
-func load(cmd *cobra.Command, path string, data interface{}) error {
-	return bite.TryReadFile(path, data)
+// load decodes a landscape file, after merging its overlay patch and substituting its variables.
+func load(opts overlayOptions, path string, data interface{}) error {
+	b, err := opts.render(path)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalByExt(path, b, data)
+}
+
+// renderText returns a plain text landscape file, e.g. the SQL of a processor, with its variables substituted and its secrets resolved.
+// The overlay patch of a text file replaces it as a whole.
+func (o overlayOptions) renderText(path string) (string, error) {
+	file := path
+	if patchFile, ok := o.overlayPath(path); ok && isRegularFile(patchFile) {
+		file = patchFile
+	}
+
+	b, err := readLandscapeFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	if b, err = o.substitute(file, b); err != nil {
+		return "", err
+	}
+
+	return o.resolveSecretString(string(b))
 }
@@ -0,0 +1,62 @@
+package imports
+
+import (
+	"fmt"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/utils"
+)
+
+// checkManifest warns when the landscape was exported from a different Lenses or its files changed since the export.
+func checkManifest(client *api.Client, dir string, basePaths ...string) {
+	// a single file landscape has no manifest.
+	if isRegularFile(dir) {
+		return
+	}
+
+	manifest, found, err := utils.ReadManifest(dir)
+	if err != nil {
+		golog.Warnf("Unable to read the landscape manifest. [%s]", err.Error())
+		return
+	}
+
+	if !found {
+		golog.Debugf("No landscape manifest found in [%s]", dir)
+		return
+	}
+
+	for _, warning := range manifestWarnings(client, manifest) {
+		golog.Warn(warning)
+	}
+
+	changes, err := manifest.VerifyFiles(dir, basePaths...)
+	if err != nil {
+		golog.Warnf("Unable to verify the landscape files against the manifest. [%s]", err.Error())
+		return
+	}
+
+	for _, change := range changes {
+		golog.Warnf("Landscape file %s since it was exported from [%s]", change, manifest.Host)
+	}
+}
+
+func manifestWarnings(client *api.Client, manifest utils.Manifest) []string {
+	cfg, err := client.GetConfig()
+	if err != nil {
+		return []string{fmt.Sprintf("Unable to retrieve the Lenses configuration to compare it with the landscape manifest. [%v]", err)}
+	}
+
+	var warnings []string
+	if manifest.LensesVersion != "" && cfg.Version != "" && manifest.LensesVersion != cfg.Version {
+		warnings = append(warnings, fmt.Sprintf("The landscape was exported from Lenses version [%s] at [%s], importing to version [%s]",
+			manifest.LensesVersion, manifest.Host, cfg.Version))
+	}
+
+	if manifest.ExecutionMode != "" && cfg.SQLExecutionMode != "" && manifest.ExecutionMode != cfg.SQLExecutionMode {
+		warnings = append(warnings, fmt.Sprintf("The landscape was exported from execution mode [%s] at [%s], importing to execution mode [%s], processors and connectors may not be compatible",
+			manifest.ExecutionMode, manifest.Host, cfg.SQLExecutionMode))
+	}
+
+	return warnings
+}
@@ -0,0 +1,28 @@
+package imports
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestWarnings(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lenses.version": "4.1.0", "lenses.sql.execution.mode": "KUBERNETES"}`))
+	})
+	client, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	warnings := manifestWarnings(client, utils.Manifest{Host: "http://dev:9991", LensesVersion: "4.1.0", ExecutionMode: api.ExecutionModeKubernetes})
+	assert.Empty(t, warnings)
+
+	warnings = manifestWarnings(client, utils.Manifest{Host: "http://dev:9991", LensesVersion: "4.0.2", ExecutionMode: api.ExecutionModeInProcess})
+	assert.Equal(t, []string{
+		"The landscape was exported from Lenses version [4.0.2] at [http://dev:9991], importing to version [4.1.0]",
+		"The landscape was exported from execution mode [IN_PROC] at [http://dev:9991], importing to execution mode [KUBERNETES], processors and connectors may not be compatible",
+	}, warnings)
+}
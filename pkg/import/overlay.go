@@ -0,0 +1,336 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	overlayFlagKey = "overlay"
+	valuesFlagKey  = "values"
+	setFlagKey     = "set"
+)
+
+// canOverlay registers the `--overlay`, `--values` and `--set` flags to a command which loads landscape files.
+func canOverlay(cmd *cobra.Command) {
+	cmd.Flags().String(overlayFlagKey, "", "Directory of per-environment patches merged over the landscape files with the same path, e.g. overlays/prod/kafka/topics/topic-orders.yaml")
+	cmd.Flags().String(valuesFlagKey, "", "YAML or JSON file of the values of the ${var} placeholders of the landscape files, nested keys are joined with dots")
+	cmd.Flags().StringArray(setFlagKey, nil, "Set the value of a ${var} placeholder, it takes precedence over --values, e.g. --set topics.partitions=6")
+}
+
+// overlayOptions controls how a landscape file is rendered before it's decoded.
+type overlayOptions struct {
+	// root is the landscape directory, the overlay mirrors its structure.
+	root    string
+	overlay string
+	values  map[string]string
+	// variables is set when any of `--overlay`, `--values` or `--set` is, otherwise the `${var}` placeholders and the `$$`
+	// escapes are left as they are, so that the landscapes exported before these flags are imported unchanged.
+	variables bool
+	// secrets are the secret placeholders found by substitute, keyed by the token which replaced them.
+	secrets map[string]secretReference
+	// resolved are the values of the secrets resolved by resolveSecrets.
+	resolved *resolvedSecrets
+	// offline leaves the secret placeholders unresolved, as their tokens, e.g. to validate a landscape without its secret stores.
+	offline bool
+}
+
+// overlayOptionsFrom reads the `--dir`, `--overlay`, `--values` and `--set` flags of a command,
+// it should be called once per command and its options passed down to the loaders of the landscape files.
+func overlayOptionsFrom(cmd *cobra.Command) (overlayOptions, error) {
+	var opts overlayOptions
+	opts.root, _ = cmd.Flags().GetString("dir")
+	opts.overlay, _ = cmd.Flags().GetString(overlayFlagKey)
+	opts.values = make(map[string]string)
+	opts.secrets = make(map[string]secretReference)
+	opts.resolved = newResolvedSecrets()
+
+	if valuesFile, _ := cmd.Flags().GetString(valuesFlagKey); valuesFile != "" {
+		b, err := ioutil.ReadFile(valuesFile)
+		if err != nil {
+			return opts, fmt.Errorf("unable to read values file [%s]. [%v]", valuesFile, err)
+		}
+
+		var values interface{}
+		if err = unmarshalByExt(valuesFile, b, &values); err != nil {
+			return opts, fmt.Errorf("unable to decode values file [%s]. [%v]", valuesFile, err)
+		}
+
+		flattenValues("", values, opts.values)
+	}
+
+	sets, _ := cmd.Flags().GetStringArray(setFlagKey)
+	for _, set := range sets {
+		kv := strings.SplitN(set, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return opts, fmt.Errorf("invalid --set [%s], expected key=value", set)
+		}
+		opts.values[kv[0]] = kv[1]
+	}
+
+	valuesFile, _ := cmd.Flags().GetString(valuesFlagKey)
+	opts.variables = opts.overlay != "" || valuesFile != "" || len(sets) > 0
+
+	return opts, nil
+}
+
+func flattenValues(prefix string, v interface{}, values map[string]string) {
+	join := func(k interface{}) string {
+		if prefix == "" {
+			return fmt.Sprint(k)
+		}
+		return prefix + "." + fmt.Sprint(k)
+	}
+
+	switch value := v.(type) {
+	case map[interface{}]interface{}:
+		for k, item := range value {
+			flattenValues(join(k), item, values)
+		}
+	case map[string]interface{}:
+		for k, item := range value {
+			flattenValues(join(k), item, values)
+		}
+	case nil:
+		values[prefix] = ""
+	default:
+		values[prefix] = scalarString(value)
+	}
+}
+
+// placeholderExpr matches the `${var}` and the secret placeholders and the `$$` escape,
+// the `${file:/path:key}` config providers of Kafka Connect are left as they are.
+var placeholderExpr = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}|\$\{(vault|azure|env):([^}]+)\}`)
+
+// substitute replaces the `${var}` placeholders of a landscape file with their values, if any `--overlay`, `--values` or `--set` is given,
+// and the secret placeholders with tokens, resolved by resolveSecrets once the file is decoded.
+func (o overlayOptions) substitute(file string, b []byte) ([]byte, error) {
+	var undefined []string
+
+	out := placeholderExpr.ReplaceAllFunc(b, func(match []byte) []byte {
+		if ref, ok := parseSecretReference(string(match)); ok {
+			token := fmt.Sprintf("__secret_%d__", len(o.secrets))
+			o.secrets[token] = ref
+			return []byte(token)
+		}
+
+		if !o.variables {
+			return match
+		}
+
+		if string(match) == "$$" {
+			return []byte("$")
+		}
+
+		name := string(match[2 : len(match)-1])
+		value, ok := o.values[name]
+		if !ok {
+			undefined = append(undefined, name)
+			return match
+		}
+
+		return []byte(value)
+	})
+
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined variables [%s] in [%s], set them with --values or --set", strings.Join(undefined, ", "), file)
+	}
+
+	return out, nil
+}
+
+// overlayPath returns the path of the patch of a landscape file, if an overlay is set.
+func (o overlayOptions) overlayPath(file string) (string, bool) {
+	if o.overlay == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(o.root, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+
+	return filepath.Join(o.overlay, rel), true
+}
+
+// render returns the contents of a landscape file with its overlay patch merged, its variables substituted and its secrets resolved,
+// a file may exist only in the overlay, e.g. a topic which exists only in production. The files which are documents are replaced by their spec.
+func (o overlayOptions) render(file string) ([]byte, error) {
+	base, err := readLandscapeFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	baseFound := err == nil
+
+	var patch []byte
+	patchFound := false
+	if patchFile, ok := o.overlayPath(file); ok {
+		if patch, err = ioutil.ReadFile(patchFile); err == nil {
+			patchFound = true
+			if patch, err = o.substitute(patchFile, patch); err != nil {
+				return nil, err
+			}
+			if patch, err = o.unwrapDocument(patchFile, patch); err != nil {
+				return nil, err
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if !baseFound {
+		if !patchFound {
+			return nil, fmt.Errorf("file [%s] not found", file)
+		}
+		return o.resolveSecrets(file, patch)
+	}
+
+	if base, err = o.substitute(file, base); err != nil {
+		return nil, err
+	}
+
+	if base, err = o.unwrapDocument(file, base); err != nil {
+		return nil, err
+	}
+
+	if !patchFound {
+		return o.resolveSecrets(file, base)
+	}
+
+	var baseDoc, patchDoc interface{}
+	if err = unmarshalByExt(file, base, &baseDoc); err != nil {
+		return nil, err
+	}
+	if err = unmarshalByExt(file, patch, &patchDoc); err != nil {
+		return nil, fmt.Errorf("unable to decode the overlay of [%s]. [%v]", file, err)
+	}
+
+	merged, err := marshalByExt(file, mergePatch(baseDoc, patchDoc))
+	if err != nil {
+		return nil, err
+	}
+
+	return o.resolveSecrets(file, merged)
+}
+
+// mergePatch merges a patch over a document like a JSON merge patch (RFC 7386):
+// objects are merged key by key, a null value removes the key and any other value, lists included, replaces it.
+func mergePatch(doc, patch interface{}) interface{} {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		d, ok := doc.(map[string]interface{})
+		if !ok {
+			d = make(map[string]interface{})
+		}
+
+		for k, v := range p {
+			if v == nil {
+				delete(d, k)
+				continue
+			}
+			d[k] = mergePatch(d[k], v)
+		}
+		return d
+	case map[interface{}]interface{}:
+		d, ok := doc.(map[interface{}]interface{})
+		if !ok {
+			d = make(map[interface{}]interface{})
+		}
+
+		for k, v := range p {
+			if v == nil {
+				delete(d, k)
+				continue
+			}
+			d[k] = mergePatch(d[k], v)
+		}
+		return d
+	default:
+		return patch
+	}
+}
+
+// unmarshalByExt decodes YAML files by their extension and everything else as JSON, like `bite.TryReadFile`.
+func unmarshalByExt(file string, b []byte, outPtr interface{}) error {
+	switch filepath.Ext(file) {
+	case ".yml", ".yaml":
+		return yaml.Unmarshal(b, outPtr)
+	default:
+		return json.Unmarshal(b, outPtr)
+	}
+}
+
+func marshalByExt(file string, v interface{}) ([]byte, error) {
+	switch filepath.Ext(file) {
+	case ".yml", ".yaml":
+		return yaml.Marshal(v)
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// landscapeFiles returns the files of a resource directory of the landscape and of its overlay, sorted by name,
+// with the documents of the multi-document landscape files of its kind.
+func landscapeFiles(opts overlayOptions, loadpath string) ([]os.FileInfo, error) {
+	byName := make(map[string]os.FileInfo)
+
+	var dirs []string
+	// a single file landscape has no resource directories.
+	if !isRegularFile(opts.root) {
+		dirs = append(dirs, loadpath)
+	}
+	if overlayDir, ok := opts.overlayPath(loadpath); ok {
+		dirs = append(dirs, overlayDir)
+	}
+
+	found := false
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		found = true
+		for _, file := range files {
+			if _, ok := byName[file.Name()]; !ok && !file.IsDir() {
+				byName[file.Name()] = file
+			}
+		}
+	}
+
+	if opts.root != "" {
+		documents, err := registerDocuments(opts.root, loadpath, byName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, document := range documents {
+			byName[document.Name()] = document
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("directory [%s] not found", loadpath)
+	}
+
+	files := make([]os.FileInfo, 0, len(byName))
+	for _, file := range byName {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+
+	return files, nil
+}
@@ -0,0 +1,115 @@
+package imports
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadWithOverlayAndValues(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-overlay", nil)
+	defer remove()
+
+	base := filepath.Join(dir, "base")
+	overlay := filepath.Join(dir, "overlays", "prod")
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		"base/" + pkg.TopicsPath + "/topic-orders.yaml": `name: orders
+partitions: 3
+replication: ${replication}
+configs:
+  cleanup.policy: delete
+  retention.ms: "604800000"
+  segment.bytes: "1073741824"
+`,
+		"overlays/prod/" + pkg.TopicsPath + "/topic-orders.yaml": `partitions: ${orders.partitions}
+configs:
+  retention.ms: "2592000000"
+  segment.bytes: null
+`,
+		"overlays/prod/" + pkg.TopicsPath + "/topic-audit.yaml":        "name: audit\npartitions: 1\nreplication: ${replication}\n",
+		"overlays/prod/" + pkg.ConnectorsPath + "/connector-sink.json": `{"name": "sink", "config": {"password": "${file:/secrets.properties:password}", "cost": "$${price}"}}`,
+		"values-prod.yaml": "replication: 3\norders:\n  partitions: 12\n",
+	})
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dir", base, "")
+	canOverlay(cmd)
+	assert.Nil(t, cmd.Flags().Set(overlayFlagKey, overlay))
+	assert.Nil(t, cmd.Flags().Set(valuesFlagKey, filepath.Join(dir, "values-prod.yaml")))
+	assert.Nil(t, cmd.Flags().Set(setFlagKey, "replication=2"))
+
+	opts, err := overlayOptionsFrom(cmd)
+	assert.Nil(t, err)
+
+	topicsDir := filepath.Join(base, pkg.TopicsPath)
+	files, err := landscapeFiles(opts, topicsDir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "topic-audit.yaml", files[0].Name())
+
+	var orders api.CreateTopicPayload
+	assert.Nil(t, load(opts, filepath.Join(topicsDir, "topic-orders.yaml"), &orders))
+	assert.Equal(t, api.CreateTopicPayload{
+		TopicName:   "orders",
+		Partitions:  12,
+		Replication: 2,
+		Configs:     api.KV{"cleanup.policy": "delete", "retention.ms": "2592000000"},
+	}, orders)
+
+	var audit api.CreateTopicPayload
+	assert.Nil(t, load(opts, filepath.Join(topicsDir, "topic-audit.yaml"), &audit))
+	assert.Equal(t, "audit", audit.TopicName)
+	assert.Equal(t, 2, audit.Replication)
+
+	// config providers of Kafka Connect are not variables and `$$` escapes a `$`.
+	var connector api.CreateUpdateConnectorPayload
+	assert.Nil(t, load(opts, filepath.Join(base, pkg.ConnectorsPath, "connector-sink.json"), &connector))
+	assert.Equal(t, "${file:/secrets.properties:password}", connector.Config["password"])
+	assert.Equal(t, "${price}", connector.Config["cost"])
+
+	// without --overlay, --values and --set the files are loaded as they are.
+	cmd = &cobra.Command{}
+	cmd.Flags().String("dir", filepath.Join(dir, "overlays", "prod"), "")
+	canOverlay(cmd)
+	opts, err = overlayOptionsFrom(cmd)
+	assert.Nil(t, err)
+	connector = api.CreateUpdateConnectorPayload{}
+	assert.Nil(t, load(opts, filepath.Join(overlay, pkg.ConnectorsPath, "connector-sink.json"), &connector))
+	assert.Equal(t, "$${price}", connector.Config["cost"])
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("dir", base, "")
+	canOverlay(cmd)
+	assert.Nil(t, cmd.Flags().Set(setFlagKey, "partitions=6"))
+	opts, err = overlayOptionsFrom(cmd)
+	assert.Nil(t, err)
+	err = load(opts, filepath.Join(topicsDir, "topic-orders.yaml"), &orders)
+	assert.EqualError(t, err, "undefined variables [replication] in ["+filepath.Join(topicsDir, "topic-orders.yaml")+"], set them with --values or --set")
+}
+
+func TestImportInvalidValuesFile(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "landscape-values", map[string]string{
+		pkg.TopicsPath + "/topic-orders.yaml": "name: orders\npartitions: ${partitions}\nreplication: 1\n",
+		"values.yaml":                         "partitions: [12\n",
+	})
+	defer remove()
+
+	requested := false
+	_, teardown := test.UsingTestingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Write([]byte(`[]`))
+	}))
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--values", filepath.Join(dir, "values.yaml"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to decode values file")
+	assert.False(t, requested)
+}
@@ -0,0 +1,546 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// ErrPlanNotEmpty is returned by the import commands when `--dry-run` and `--detailed-exitcode` are set
+// and the plan contains at least one create, update or delete change.
+var ErrPlanNotEmpty = errors.New("the import plan is not empty")
+
+type planAction string
+
+const (
+	planCreate planAction = "create"
+	planUpdate planAction = "update"
+	planDelete planAction = "delete"
+	planNoop   planAction = "no-op"
+	// planInvalid is the action of a landscape file which cannot be loaded, see `--ignore-errors`.
+	planInvalid planAction = "invalid"
+)
+
+type (
+	// fieldDiff is a single field which differs between the live and the desired state of a resource,
+	// nested fields are separated by dots, e.g. `configs.retention.ms`.
+	fieldDiff struct {
+		Field   string      `json:"field" yaml:"field"`
+		Live    interface{} `json:"live" yaml:"live"`
+		Desired interface{} `json:"desired" yaml:"desired"`
+	}
+
+	// planChange is a single step of an import plan, "apply" is nil for no-op changes.
+	planChange struct {
+		Kind   string      `json:"kind" yaml:"kind"`
+		Name   string      `json:"name" yaml:"name"`
+		Action planAction  `json:"action" yaml:"action"`
+		File   string      `json:"file,omitempty" yaml:"file,omitempty"`
+		Diff   []fieldDiff `json:"diff,omitempty" yaml:"diff,omitempty"`
+
+		apply         func() error
+		live, desired interface{}
+		// secrets are redacted from the drift of the change.
+		secrets *resolvedSecrets
+		err     error
+	}
+
+	// planSummary counts the changes of a plan per action.
+	planSummary struct {
+		Create  int `json:"create" yaml:"create"`
+		Update  int `json:"update" yaml:"update"`
+		Delete  int `json:"delete" yaml:"delete"`
+		Noop    int `json:"noop" yaml:"noop"`
+		Invalid int `json:"invalid,omitempty" yaml:"invalid,omitempty"`
+	}
+
+	importPlan struct {
+		Changes []planChange `json:"changes" yaml:"changes"`
+		Summary planSummary  `json:"summary" yaml:"summary"`
+	}
+
+	// applyResult is the outcome of applying a single change of a plan.
+	applyResult struct {
+		Kind   string     `json:"kind" yaml:"kind" header:"Kind"`
+		Name   string     `json:"name" yaml:"name" header:"Name"`
+		Action planAction `json:"action" yaml:"action" header:"Action"`
+		Status string     `json:"status" yaml:"status" header:"Status"`
+		Error  string     `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+	}
+)
+
+const (
+	applySucceeded = "succeeded"
+	applyFailed    = "failed"
+	// applySkipped is the status of the changes which were not applied because of an earlier failure.
+	applySkipped = "skipped"
+)
+
+const (
+	dryRunFlagKey           = "dry-run"
+	detailedExitCodeFlagKey = "detailed-exitcode"
+	concurrencyFlagKey      = "concurrency"
+	ignoreErrorsFlagKey     = "ignore-errors"
+)
+
+// canPlan registers the `--dry-run`, `--detailed-exitcode`, `--concurrency` and `--ignore-errors` flags to an import command,
+// and the flags of the snapshot taken before the changes are applied.
+func canPlan(cmd *cobra.Command) {
+	cmd.Flags().Bool(dryRunFlagKey, false, "Print the plan of the changes against the live state without applying them")
+	cmd.Flags().Bool(detailedExitCodeFlagKey, false, "Exit with a non-zero code if the plan of a --dry-run contains changes")
+	cmd.Flags().Int(concurrencyFlagKey, 1, "Number of changes applied in parallel")
+	cmd.Flags().Bool(ignoreErrorsFlagKey, false, "Skip the files that cannot be loaded and keep applying the changes after a failure")
+	canSnapshot(cmd)
+}
+
+// invalidFile returns the change which reports a landscape file that cannot be loaded,
+// it returns false unless `--ignore-errors` is set, the caller should then fail with the load error.
+func invalidFile(cmd *cobra.Command, kind, file string, err error) (planChange, bool) {
+	if ignore, _ := cmd.Flags().GetBool(ignoreErrorsFlagKey); !ignore {
+		return planChange{}, false
+	}
+
+	golog.Errorf("Skipping %s file [%s]. [%s]", kind, file, err.Error())
+	return planChange{Kind: kind, Name: filepath.Base(file), File: file, Action: planInvalid, err: err}, true
+}
+
+// newChange compares the "live" and the "desired" state of a resource and returns a create, update or no-op change,
+// "live" should be nil when the resource does not exist. Both states are compared through their JSON form.
+func (o overlayOptions) newChange(kind, name, file string, live, desired interface{}, apply func() error) (planChange, error) {
+	change := planChange{Kind: kind, Name: name, File: file, apply: apply, live: live, desired: desired, secrets: o.resolved}
+
+	if live == nil || (reflect.ValueOf(live).Kind() == reflect.Ptr && reflect.ValueOf(live).IsNil()) {
+		change.Action = planCreate
+		change.live = nil
+		return change, nil
+	}
+
+	diff, err := diffFields(live, desired)
+	if err != nil {
+		return change, fmt.Errorf("unable to compare %s [%s]. [%v]", kind, name, err)
+	}
+
+	if len(diff) == 0 {
+		change.Action = planNoop
+		change.apply = nil
+		return change, nil
+	}
+
+	for i := range diff {
+		diff[i].Live = o.resolved.redact(diff[i].Live)
+		diff[i].Desired = o.resolved.redact(diff[i].Desired)
+	}
+
+	change.Action = planUpdate
+	change.Diff = diff
+	return change, nil
+}
+
+// diffFields returns the fields that differ between "live" and "desired".
+func diffFields(live, desired interface{}) ([]fieldDiff, error) {
+	l, err := asGeneric(live)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := asGeneric(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []fieldDiff
+	compareValues("", l, d, &diff)
+	return diff, nil
+}
+
+func asGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(b, &generic)
+	return generic, err
+}
+
+func compareValues(field string, live, desired interface{}, diff *[]fieldDiff) {
+	liveObj, liveIsObj := live.(map[string]interface{})
+	desiredObj, desiredIsObj := desired.(map[string]interface{})
+
+	if liveIsObj && desiredIsObj {
+		keys := make(map[string]struct{}, len(liveObj)+len(desiredObj))
+		for k := range liveObj {
+			keys[k] = struct{}{}
+		}
+		for k := range desiredObj {
+			keys[k] = struct{}{}
+		}
+
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		for _, k := range names {
+			name := k
+			if field != "" {
+				name = field + "." + k
+			}
+
+			compareValues(name, liveObj[k], desiredObj[k], diff)
+		}
+
+		return
+	}
+
+	if !sameValue(live, desired) {
+		*diff = append(*diff, fieldDiff{Field: field, Live: live, Desired: desired})
+	}
+}
+
+// sameValue reports whether two decoded JSON values are equal, scalars by their text form.
+func sameValue(live, desired interface{}) bool {
+	if isEmptyValue(live) && isEmptyValue(desired) {
+		return true
+	}
+
+	liveArr, liveIsArr := live.([]interface{})
+	desiredArr, desiredIsArr := desired.([]interface{})
+	if liveIsArr && desiredIsArr {
+		if len(liveArr) != len(desiredArr) {
+			return false
+		}
+
+		for i := range liveArr {
+			var diff []fieldDiff
+			compareValues("", liveArr[i], desiredArr[i], &diff)
+			if len(diff) > 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if liveIsArr || desiredIsArr {
+		return false
+	}
+
+	_, liveIsObj := live.(map[string]interface{})
+	_, desiredIsObj := desired.(map[string]interface{})
+	if liveIsObj || desiredIsObj {
+		return reflect.DeepEqual(live, desired)
+	}
+
+	if live == nil || desired == nil {
+		return false
+	}
+
+	return scalarString(live) == scalarString(desired)
+}
+
+// scalarString returns the text form of a decoded JSON scalar, numbers without exponent.
+func scalarString(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// isEmptyValue reports whether a decoded JSON value is null or an empty array or object.
+func isEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
+
+func (p importPlan) empty() bool {
+	return p.Summary.Create+p.Summary.Update+p.Summary.Delete+p.Summary.Invalid == 0
+}
+
+func newImportPlan(changes []planChange) importPlan {
+	plan := importPlan{Changes: withoutPrune(changes)}
+	if plan.Changes == nil {
+		plan.Changes = []planChange{}
+	}
+
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case planCreate:
+			plan.Summary.Create++
+		case planUpdate:
+			plan.Summary.Update++
+		case planDelete:
+			plan.Summary.Delete++
+		case planInvalid:
+			plan.Summary.Invalid++
+		default:
+			plan.Summary.Noop++
+		}
+	}
+
+	return plan
+}
+
+// withoutPrune drops the deletions of the `--prune` when a file could not be loaded, see `--ignore-errors`,
+// the resources of that file are not in the plan and their live ones would be deleted.
+func withoutPrune(changes []planChange) []planChange {
+	invalid, deletes := false, false
+	for _, change := range changes {
+		invalid = invalid || change.Action == planInvalid
+		deletes = deletes || change.Action == planDelete
+	}
+
+	if !invalid || !deletes {
+		return changes
+	}
+
+	golog.Warnf("Skipping the prune, some landscape files could not be loaded")
+	kept := make([]planChange, 0, len(changes))
+	for _, change := range changes {
+		if change.Action != planDelete {
+			kept = append(kept, change)
+		}
+	}
+
+	return kept
+}
+
+// runPlan prints the plan when `--dry-run` is set, otherwise it applies its changes in order.
+func runPlan(cmd *cobra.Command, changes []planChange) error {
+	plan := newImportPlan(changes)
+
+	if dryRun, _ := cmd.Flags().GetBool(dryRunFlagKey); dryRun {
+		if err := printPlan(cmd, plan); err != nil {
+			return err
+		}
+
+		if detailed, _ := cmd.Flags().GetBool(detailedExitCodeFlagKey); detailed && !plan.empty() {
+			return ErrPlanNotEmpty
+		}
+
+		return nil
+	}
+
+	if err := confirmDeletes(cmd, plan); err != nil {
+		return err
+	}
+
+	var snapshot *importSnapshot
+	if !plan.empty() {
+		landscape, _ := cmd.Flags().GetString("dir")
+		var err error
+		if snapshot, err = takeSnapshot(config.Client, cmd, landscape, []string{cmd.Name()}); err != nil {
+			return err
+		}
+		if err = snapshot.recordCreated(cmd.Name(), plan.Changes); err != nil {
+			return err
+		}
+	}
+
+	results, err := applyPlan(cmd, plan)
+	if len(results) > 0 {
+		if printErr := bite.PrintObject(cmd, results); printErr != nil {
+			return printErr
+		}
+	}
+
+	if err != nil {
+		snapshot.warnRollback()
+	}
+
+	return err
+}
+
+// applyPlan applies the changes of a plan with a pool of `--concurrency` workers and returns their results in the order of the plan.
+// Unless `--ignore-errors` is set, the changes not yet started when one fails are skipped and an error is returned.
+func applyPlan(cmd *cobra.Command, plan importPlan) ([]applyResult, error) {
+	concurrency, _ := cmd.Flags().GetInt(concurrencyFlagKey)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ignoreErrors, _ := cmd.Flags().GetBool(ignoreErrorsFlagKey)
+
+	var changes []planChange
+	for _, change := range plan.Changes {
+		if change.Action == planNoop {
+			golog.Debugf("%s [%s] is up to date", change.Kind, change.Name)
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if concurrency > 1 {
+		// the changes may print to the command's output.
+		cmd.SetOut(utils.NewSyncWriter(cmd.OutOrStdout()))
+	}
+
+	var (
+		results = make([]applyResult, len(changes))
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stopped bool
+		failed  int
+	)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mu.Lock()
+				stop := stopped
+				mu.Unlock()
+
+				if stop {
+					results[i] = newApplyResult(changes[i], applySkipped, nil)
+					continue
+				}
+
+				results[i] = applyChange(changes[i])
+				if results[i].Status == applyFailed {
+					mu.Lock()
+					failed++
+					stopped = !ignoreErrors
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range changes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed == 0 {
+		return results, nil
+	}
+
+	if ignoreErrors {
+		golog.Warnf("[%d] of [%d] changes failed and were ignored", failed, len(changes))
+		return results, nil
+	}
+
+	return results, fmt.Errorf("failed to apply [%d] of [%d] changes", failed, len(changes))
+}
+
+func applyChange(change planChange) applyResult {
+	if change.Action == planInvalid {
+		return newApplyResult(change, applyFailed, change.err)
+	}
+
+	if err := change.apply(); err != nil {
+		golog.Errorf("Failed to %s %s [%s]. [%s]", change.Action, change.Kind, change.Name, err.Error())
+		return newApplyResult(change, applyFailed, err)
+	}
+
+	golog.Infof("%s %s [%s]", actionPastTense(change.Action), change.Kind, change.Name)
+	return newApplyResult(change, applySucceeded, nil)
+}
+
+func newApplyResult(change planChange, status string, err error) applyResult {
+	result := applyResult{Kind: change.Kind, Name: change.Name, Action: change.Action, Status: status}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func actionPastTense(action planAction) string {
+	switch action {
+	case planCreate:
+		return "Created"
+	case planUpdate:
+		return "Updated"
+	case planDelete:
+		return "Deleted"
+	default:
+		return "Skipped"
+	}
+}
+
+func printPlan(cmd *cobra.Command, plan importPlan) error {
+	if output := strings.ToUpper(bite.GetOutPutFlag(cmd)); output == "JSON" || output == "YAML" {
+		return bite.PrintObject(cmd, plan)
+	}
+
+	writePlan(cmd.OutOrStdout(), plan)
+	return nil
+}
+
+// writePlan writes the human readable form of the plan.
+func writePlan(w io.Writer, plan importPlan) {
+	for _, change := range plan.Changes {
+		var symbol string
+		switch change.Action {
+		case planCreate:
+			symbol = "+"
+		case planUpdate:
+			symbol = "~"
+		case planDelete:
+			symbol = "-"
+		case planInvalid:
+			fmt.Fprintf(w, "! %s file [%s] cannot be loaded. [%v]\n", change.Kind, change.File, change.err)
+			continue
+		default:
+			continue
+		}
+
+		fmt.Fprintf(w, "%s %s [%s] will be %sd", symbol, change.Kind, change.Name, change.Action)
+		if change.File != "" {
+			fmt.Fprintf(w, " (%s)", change.File)
+		}
+		fmt.Fprintln(w)
+
+		for _, d := range change.Diff {
+			fmt.Fprintf(w, "    %s: %s => %s\n", d.Field, formatPlanValue(d.Live), formatPlanValue(d.Desired))
+		}
+	}
+
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete, %d unchanged",
+		plan.Summary.Create, plan.Summary.Update, plan.Summary.Delete, plan.Summary.Noop)
+	if plan.Summary.Invalid > 0 {
+		fmt.Fprintf(w, ", %d invalid files", plan.Summary.Invalid)
+	}
+	fmt.Fprintln(w, ".")
+}
+
+func formatPlanValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
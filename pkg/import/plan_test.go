@@ -0,0 +1,193 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFields(t *testing.T) {
+	live := api.CreateTopicPayload{
+		TopicName:   "orders",
+		Partitions:  3,
+		Replication: 1,
+		Configs:     api.KV{"retention.ms": "1000", "cleanup.policy": "delete"},
+	}
+	desired := api.CreateTopicPayload{
+		TopicName:   "orders",
+		Partitions:  6,
+		Replication: 1,
+		Configs:     api.KV{"retention.ms": 1000, "cleanup.policy": "compact"},
+	}
+
+	diff, err := diffFields(live, desired)
+	assert.Nil(t, err)
+	assert.Equal(t, []fieldDiff{
+		{Field: "configs.cleanup.policy", Live: "delete", Desired: "compact"},
+		{Field: "partitions", Live: float64(3), Desired: float64(6)},
+	}, diff)
+}
+
+func TestNewChange(t *testing.T) {
+	desired := api.ServiceAccount{Name: "pam", Groups: []string{"foo"}}
+
+	change, err := overlayOptions{}.newChange("service account", "pam", "", nil, desired, func() error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, planCreate, change.Action)
+	assert.NotNil(t, change.apply)
+
+	change, err = overlayOptions{}.newChange("service account", "pam", "", api.ServiceAccount{Name: "pam", Groups: []string{"foo"}}, desired, func() error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, planNoop, change.Action)
+	assert.Nil(t, change.apply)
+
+	change, err = overlayOptions{}.newChange("service account", "pam", "", api.ServiceAccount{Name: "pam", Groups: []string{"bar"}}, desired, func() error { return nil })
+	assert.Nil(t, err)
+	assert.Equal(t, planUpdate, change.Action)
+	assert.Equal(t, []fieldDiff{{Field: "groups", Live: []interface{}{"bar"}, Desired: []interface{}{"foo"}}}, change.Diff)
+}
+
+const planServiceAccountsResp = `[
+  {"name": "pam", "owner": "paul", "groups": ["foo"]},
+  {"name": "sam", "owner": "paul", "groups": ["bar"]}
+]`
+
+func writePlanServiceAccounts(t *testing.T) (string, func()) {
+	return test.TempLandscape(t, "import-plan", map[string]string{
+		pkg.ServiceAccountsPath + "/pam.yaml": "name: pam\nowner: paul\ngroups:\n- foo\n",
+		pkg.ServiceAccountsPath + "/sam.yaml": "name: sam\nowner: paul\ngroups:\n- foo\n",
+		pkg.ServiceAccountsPath + "/tim.yaml": "name: tim\ngroups:\n- foo\n",
+	})
+}
+
+func TestImportDryRunPrintsPlan(t *testing.T) {
+	dir, remove := writePlanServiceAccounts(t)
+	defer remove()
+
+	var mutations int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutations++
+		}
+		w.Write([]byte(planServiceAccountsResp))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportServiceAccountsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, mutations)
+
+	var plan importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &plan))
+	assert.Equal(t, planSummary{Create: 1, Update: 1, Noop: 1}, plan.Summary)
+
+	for _, change := range plan.Changes {
+		switch change.Name {
+		case "pam":
+			assert.Equal(t, planNoop, change.Action)
+		case "sam":
+			assert.Equal(t, planUpdate, change.Action)
+			assert.Equal(t, []fieldDiff{{Field: "groups", Live: []interface{}{"bar"}, Desired: []interface{}{"foo"}}}, change.Diff)
+		case "tim":
+			assert.Equal(t, planCreate, change.Action)
+		}
+	}
+
+	cmd = test.WithOutput(NewImportServiceAccountsCommand(), "table")
+	output, err = test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--detailed-exitcode")
+	assert.True(t, errors.Is(err, ErrPlanNotEmpty))
+	assert.Equal(t, 0, mutations)
+	assert.Contains(t, output, "~ service account [sam] will be updated")
+	assert.Contains(t, output, `    groups: ["bar"] => ["foo"]`)
+	assert.Contains(t, output, "+ service account [tim] will be created")
+	assert.Contains(t, output, "Plan: 1 to create, 1 to update, 0 to delete, 1 unchanged.")
+}
+
+func TestImportConcurrentlyIgnoringErrors(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-apply", map[string]string{
+		pkg.TopicsPath + "/topic-a.yaml":   "name: a\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-b.yaml":   "name: b\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-bad.yaml": "name: bad\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-c.yaml":   "name: c\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-d.yaml":   "name: [d\n",
+	})
+	defer remove()
+
+	var (
+		mu      sync.Mutex
+		created []string
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`[]`))
+			return
+		}
+
+		var payload api.CreateTopicPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload.TopicName == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`invalid topic`))
+			return
+		}
+
+		mu.Lock()
+		created = append(created, payload.TopicName)
+		mu.Unlock()
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Error(t, err)
+	assert.Empty(t, created)
+
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--concurrency", "3", "--ignore-errors")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, created)
+
+	var results []applyResult
+	assert.Nil(t, json.Unmarshal([]byte(output), &results))
+	assert.Len(t, results, 5)
+
+	statuses := make(map[string]string)
+	for _, result := range results {
+		statuses[result.Name] = result.Status
+		if result.Status == applyFailed {
+			assert.NotEmpty(t, result.Error)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"a":            applySucceeded,
+		"b":            applySucceeded,
+		"bad":          applyFailed,
+		"c":            applySucceeded,
+		"topic-d.yaml": applyFailed,
+	}, statuses)
+
+	created = nil
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	assert.Nil(t, os.Remove(filepath.Join(dir, pkg.TopicsPath, "topic-d.yaml")))
+	output, err = test.ExecuteCommand(cmd, "--dir", dir)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a", "b"}, created)
+
+	// the usage is printed after the results on failure.
+	results = nil
+	assert.Nil(t, json.NewDecoder(strings.NewReader(output)).Decode(&results))
+	assert.Equal(t, applySkipped, results[len(results)-1].Status)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,71 +23,117 @@ func NewImportPoliciesCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.PoliciesPath)
-			if err := loadPolicies(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.PoliciesPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.PoliciesPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planPolicies(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load policies. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadPolicies(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planPolicies(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading data policies from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	polices, err := client.GetPolicies()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var changes []planChange
+	described := make(map[string]bool)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
 
 		var policy api.DataPolicyRequest
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &policy); err != nil {
-			return err
+		if err := load(opts, importFilePath, &policy); err != nil {
+			if change, ok := invalidFile(cmd, "data policy", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			return nil, err
 		}
 
-		found := false
+		payload := api.DataPolicyUpdateRequest{
+			Name:        policy.Name,
+			Category:    policy.Category,
+			ImpactType:  policy.ImpactType,
+			Obfuscation: policy.Obfuscation,
+			Datasets:    policy.Datasets,
+			Fields:      policy.Fields,
+		}
+
+		var (
+			live  interface{}
+			apply = func() error {
+				return client.CreatePolicy(policy)
+			}
+		)
 
 		for _, p := range polices {
 			if p.Name == policy.Name {
-				found = true
-
-				payload := api.DataPolicyUpdateRequest{
-					ID:          p.ID,
+				live = api.DataPolicyUpdateRequest{
 					Name:        p.Name,
 					Category:    p.Category,
 					ImpactType:  p.ImpactType,
 					Obfuscation: p.Obfuscation,
+					Datasets:    p.Datasets,
 					Fields:      p.Fields,
 				}
 
-				if err := client.UpdatePolicy(payload); err != nil {
-					golog.Errorf("Error updating data policy [%s]. [%s]", p.Name, err.Error())
-					return err
+				update := payload
+				update.ID = p.ID
+				apply = func() error {
+					return client.UpdatePolicy(update)
 				}
-				golog.Infof("Updated policy [%s]", p.Name)
+				break
 			}
 		}
 
-		if !found {
-			if err := client.CreatePolicy(policy); err != nil {
-				golog.Errorf("Error creating data policy [%s]. [%s]", policy.Name, err.Error())
-				return err
-			}
-			golog.Infof("Created data policy [%s]", policy.Name)
+		change, err := opts.newChange("data policy", policy.Name, importFilePath, live, payload, apply)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+		described[policy.Name] = true
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	for _, p := range polices {
+		if described[p.Name] {
+			continue
+		}
+
+		id := p.ID
+		if change, ok := prune.deleteChange("data policy", p.Name, []string{p.LastUpdatedUser}, func() error {
+			return client.DeletePolicy(id)
+		}); ok {
+			changes = append(changes, change)
 		}
 	}
 
-	return nil
+	return changes, nil
 }
@@ -2,12 +2,14 @@ package imports
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 
 	"github.com/kataras/golog"
 	"github.com/spf13/cobra"
@@ -20,81 +22,203 @@ func NewImportProcessorsCommand() *cobra.Command {
 	var path string
 
 	cmd := &cobra.Command{
-		Use:              "processors",
-		Short:            "processors",
+		Use:   "processors",
+		Short: "processors",
+		Long: `Import the processors of the landscape, a processor is either a single file, or a <name>.sql file of its SQL
+with a <name>.yaml file of its runners, cluster, namespace, pipeline and processorId, see 'export processors --sql-files'.`,
 		Example:          `import processors --dir /my-landscape --ignore-errors`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.SQLPath)
-			if err := loadProcessors(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.SQLPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.SQLPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planProcessors(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load processors. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadProcessors(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planProcessors(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading processors from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	processors, err := client.GetProcessors()
 
 	if err != nil {
 		golog.Errorf("Failed to retrieve processors. [%s]", err.Error())
+		return nil, err
 	}
 
-	for _, file := range files {
-
-		var processor api.CreateProcessorFilePayload
+	loaded, changes, err := loadProcessors(cmd, opts, loadpath, files)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &processor); err != nil {
-			return err
-		}
+	described := make(map[string]bool)
+	for _, file := range loaded {
+		importFilePath, processor := file.path, file.processor
+
+		var (
+			live  interface{}
+			apply = func() error {
+				return client.CreateProcessor(
+					processor.Name,
+					processor.SQL,
+					processor.Runners,
+					processor.ClusterName,
+					processor.Namespace,
+					processor.Pipeline,
+					processor.ProcessorID)
+			}
+		)
 
 		for _, p := range processors.Streams {
 			if processor.Name == p.Name &&
 				processor.ClusterName == p.ClusterName &&
 				processor.Namespace == p.Namespace {
 
-				if processor.Runners != p.Runners {
-					//scale
-					if err := client.UpdateProcessorRunners(p.ID, processor.Runners); err != nil {
-						golog.Errorf("Error scaling processor [%s] from file [%s/%s]. [%s]", p.ID, loadpath, file.Name(), err.Error())
-						return err
+				current := p.ProcessorAsFile()
+				// the SQL is kept in the live state so that its drift shows in the plan, white space aside.
+				sqlChanged := strings.TrimSpace(current.SQL) != strings.TrimSpace(processor.SQL)
+				if !sqlChanged {
+					current.SQL = processor.SQL
+				}
+				current.Pipeline = processor.Pipeline
+				current.ProcessorID = processor.ProcessorID
+				live = current
+
+				// Only the runners of an existing processor can be scaled.
+				id := p.ID
+				apply = func() error {
+					if sqlChanged {
+						return fmt.Errorf("the SQL of processor [%s] differs from file [%s] but only the runners of an existing processor can be updated, delete the processor to recreate it", processor.Name, importFilePath)
 					}
-					golog.Infof("Scaled processor [%s] from file [%s/%s] from [%d] to [%d]", p.ID, loadpath, file.Name(), p.Runners, processor.Runners)
-					return nil
+					return client.UpdateProcessorRunners(id, processor.Runners)
 				}
-				golog.Warnf("Processor [%s] from file [%s/%s] already exists", p.ID, loadpath, file.Name())
+				described[id] = true
+				break
 			}
 		}
 
-		if err := client.CreateProcessor(
-			processor.Name,
-			processor.SQL,
-			processor.Runners,
-			processor.ClusterName,
-			processor.Namespace,
-			processor.Pipeline,
-			processor.ProcessorID); err != nil {
-
-			golog.Errorf("Error creating processor from file [%s/%s]. [%s]", loadpath, file.Name(), err.Error())
-			return err
+		change, err := opts.newChange("processor", processor.Name, importFilePath, live, processor, apply)
+		if err != nil {
+			return nil, err
 		}
 
-		golog.Infof("Created processor from [%s/%s]", loadpath, file.Name())
+		changes = append(changes, change)
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	for _, p := range processors.Streams {
+		if described[p.ID] {
+			continue
+		}
+
+		id := p.ID
+		if change, ok := prune.deleteChange("processor", p.Name, []string{p.User}, func() error {
+			return client.DeleteProcessor(id)
+		}); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// processorFile is a processor of the landscape and the file it's loaded from.
+type processorFile struct {
+	path      string
+	processor api.CreateProcessorFilePayload
+}
+
+// loadProcessors loads the processors of the files of a resource directory, with their `.sql` files.
+func loadProcessors(cmd *cobra.Command, opts overlayOptions, loadpath string, files []os.FileInfo) ([]processorFile, []planChange, error) {
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[file.Name()] = true
+	}
+
+	var (
+		loaded  []processorFile
+		invalid []planChange
+	)
+
+	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+		ext := filepath.Ext(file.Name())
+		stem := strings.TrimSuffix(file.Name(), ext)
+
+		var (
+			processor api.CreateProcessorFilePayload
+			err       error
+		)
+
+		switch {
+		case ext == ".sql":
+			processor, err = loadSQLProcessor(opts, loadpath, stem, names)
+		case names[stem+".sql"]:
+			// the metadata of a `.sql` file.
+			continue
+		default:
+			err = load(opts, importFilePath, &processor)
+		}
+
+		if err != nil {
+			if change, ok := invalidFile(cmd, "processor", importFilePath, err); ok {
+				invalid = append(invalid, change)
+				continue
+			}
+			return nil, nil, err
+		}
+
+		loaded = append(loaded, processorFile{path: importFilePath, processor: processor})
+	}
+
+	return loaded, invalid, nil
+}
+
+// loadSQLProcessor loads the processor of a `<stem>.sql` file and of its `<stem>.yaml`, `<stem>.yml` or `<stem>.json` metadata file, if any.
+func loadSQLProcessor(opts overlayOptions, loadpath, stem string, names map[string]bool) (api.CreateProcessorFilePayload, error) {
+	var metadata api.ProcessorMetadataFilePayload
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		if names[stem+ext] {
+			if err := load(opts, fmt.Sprintf("%s/%s%s", loadpath, stem, ext), &metadata); err != nil {
+				return api.CreateProcessorFilePayload{}, err
+			}
+			break
+		}
+	}
+
+	sql, err := opts.renderText(fmt.Sprintf("%s/%s.sql", loadpath, stem))
+	if err != nil {
+		return api.CreateProcessorFilePayload{}, err
+	}
+
+	if metadata.Name == "" {
+		metadata.Name = stem
 	}
 
-	return nil
+	return metadata.WithSQL(strings.TrimSpace(sql)), nil
 }
@@ -0,0 +1,104 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportProcessorsSQLFiles(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-processors", map[string]string{
+		pkg.SQLPath + "/processor-enrich.sql":  "SET defaults.topic.autocreate=true;\nINSERT INTO ${target} SELECT STREAM * FROM orders;\n",
+		pkg.SQLPath + "/processor-enrich.yaml": "name: Enrich\nrunnerCount: 3\ncluster: IN-PROC\nnamespace: Lenses\npipeline: enrich\n",
+		pkg.SQLPath + "/audit.sql":             "INSERT INTO audit SELECT STREAM * FROM payments;\n",
+		pkg.SQLPath + "/processor-legacy.yaml": "name: Legacy\nsql: INSERT INTO legacy SELECT STREAM * FROM orders;\nrunnerCount: 1\n",
+	})
+	defer remove()
+
+	var created []api.CreateProcessorRequestPayload
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var payload api.CreateProcessorRequestPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			created = append(created, payload)
+			return
+		}
+		w.Write([]byte(`{"streams": [{"id": "1", "name": "Enrich", "runners": 2, "clusterName": "IN-PROC", "namespace": "Lenses",
+  "sql": "SET defaults.topic.autocreate=true;\nINSERT INTO enriched SELECT STREAM * FROM orders;", "pipeline": "enrich"}]}`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportProcessorsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--set", "target=enriched")
+	assert.Nil(t, err)
+
+	var p importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &p))
+	changes := make(map[string]planChange)
+	for _, change := range p.Changes {
+		changes[change.Name] = change
+	}
+
+	assert.Len(t, changes, 3)
+	assert.Equal(t, planUpdate, changes["Enrich"].Action)
+	assert.Equal(t, []fieldDiff{{Field: "runnerCount", Live: float64(2), Desired: float64(3)}}, changes["Enrich"].Diff)
+	assert.Equal(t, dir+"/"+pkg.SQLPath+"/processor-enrich.sql", changes["Enrich"].File)
+	// the name of a processor without metadata file is the name of its SQL file.
+	assert.Equal(t, planCreate, changes["audit"].Action)
+	assert.Equal(t, planCreate, changes["Legacy"].Action)
+
+	cmd = test.WithOutput(NewImportProcessorsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--no-snapshot", "--set", "target=enriched")
+	assert.Nil(t, err)
+	assert.Len(t, created, 2)
+	assert.Equal(t, "INSERT INTO audit SELECT STREAM * FROM payments;", created[0].SQL)
+}
+
+func TestImportProcessorsSQLDrift(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-processors", map[string]string{
+		pkg.SQLPath + "/enrich.sql":  "INSERT INTO enriched SELECT STREAM * FROM payments;\n",
+		pkg.SQLPath + "/enrich.yaml": "runnerCount: 1\n",
+	})
+	defer remove()
+
+	updated := false
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			updated = true
+			return
+		}
+		w.Write([]byte(`{"streams": [{"id": "1", "name": "enrich", "runners": 1, "sql": "INSERT INTO enriched SELECT STREAM * FROM orders;"}]}`))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportProcessorsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run")
+	assert.Nil(t, err)
+
+	var p importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &p))
+	assert.Len(t, p.Changes, 1)
+	assert.Equal(t, planUpdate, p.Changes[0].Action)
+	assert.Equal(t, []fieldDiff{{
+		Field:   "sql",
+		Live:    "INSERT INTO enriched SELECT STREAM * FROM orders;",
+		Desired: "INSERT INTO enriched SELECT STREAM * FROM payments;",
+	}}, p.Changes[0].Diff)
+
+	cmd = test.WithOutput(NewImportProcessorsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--detailed-exitcode")
+	assert.True(t, errors.Is(err, ErrPlanNotEmpty))
+
+	cmd = test.WithOutput(NewImportProcessorsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--no-snapshot")
+	assert.NotNil(t, err)
+	assert.False(t, updated)
+}
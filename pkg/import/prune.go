@@ -0,0 +1,106 @@
+package imports
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/kataras/golog"
+	"github.com/spf13/cobra"
+)
+
+const (
+	pruneFlagKey        = "prune"
+	pruneProtectFlagKey = "prune-protect"
+	pruneOwnerFlagKey   = "prune-owner"
+	yesFlagKey          = "yes"
+)
+
+// pruneOptions controls which of the live resources that are not described in the landscape are deleted.
+type pruneOptions struct {
+	enabled bool
+	// owner, if set, restricts the deletions to the resources whose metadata names it,
+	// e.g. a topic tag, the owner of a service account or the user who created a processor.
+	owner string
+	// protect are glob patterns of resource names that are never deleted.
+	protect []string
+}
+
+// canPrune registers the `--prune`, `--prune-protect`, `--prune-owner` and `--yes` flags to an import command.
+func canPrune(cmd *cobra.Command) {
+	cmd.Flags().Bool(pruneFlagKey, false, "Delete the live resources that are not described in the landscape")
+	cmd.Flags().StringSlice(pruneProtectFlagKey, nil, "Glob patterns of resource names that are never pruned, e.g. --prune-protect='_*,audit-*'")
+	cmd.Flags().String(pruneOwnerFlagKey, "", "Prune only the resources owned by this label: a topic tag, a service account owner, or the user who created a processor or last updated a data policy; resources without ownership metadata are not pruned")
+	cmd.Flags().Bool(yesFlagKey, false, "Do not ask for confirmation before deleting resources")
+}
+
+func pruneOptionsFrom(cmd *cobra.Command) pruneOptions {
+	var opts pruneOptions
+	opts.enabled, _ = cmd.Flags().GetBool(pruneFlagKey)
+	opts.owner, _ = cmd.Flags().GetString(pruneOwnerFlagKey)
+	opts.protect, _ = cmd.Flags().GetStringSlice(pruneProtectFlagKey)
+	return opts
+}
+
+// deleteChange returns the change which deletes a live resource not described in the landscape,
+// false if it is protected or not owned by the `--prune-owner`.
+func (o pruneOptions) deleteChange(kind, name string, owners []string, apply func() error) (planChange, bool) {
+	if !o.enabled {
+		return planChange{}, false
+	}
+
+	for _, pattern := range o.protect {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			golog.Debugf("Not pruning %s [%s], it's protected by [%s]", kind, name, pattern)
+			return planChange{}, false
+		}
+	}
+
+	if o.owner != "" {
+		owned := false
+		for _, owner := range owners {
+			if owner == o.owner {
+				owned = true
+				break
+			}
+		}
+
+		if !owned {
+			golog.Debugf("Not pruning %s [%s], it's not owned by [%s]", kind, name, o.owner)
+			return planChange{}, false
+		}
+	}
+
+	return planChange{Kind: kind, Name: name, Action: planDelete, apply: apply}, true
+}
+
+// confirmDeletes asks the user to confirm the deletions of a plan, unless `--yes` is set.
+func confirmDeletes(cmd *cobra.Command, plan importPlan) error {
+	if plan.Summary.Delete == 0 {
+		return nil
+	}
+
+	if yes, _ := cmd.Flags().GetBool(yesFlagKey); yes {
+		return nil
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action == planDelete {
+			fmt.Fprintf(cmd.OutOrStdout(), "- %s [%s] will be deleted\n", change.Kind, change.Name)
+		}
+	}
+
+	confirmed := false
+	if err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Delete [%d] resources that are not described in the landscape?", plan.Summary.Delete),
+		Default: false,
+	}, &confirmed, nil); err != nil {
+		return fmt.Errorf("unable to confirm the deletion of [%d] resources, use --yes to confirm it non-interactively. [%v]", plan.Summary.Delete, err)
+	}
+
+	if !confirmed {
+		return fmt.Errorf("the deletion of [%d] resources was not confirmed", plan.Summary.Delete)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const pruneTopicsResp = `[
+  {"topicName": "orders", "partitions": 1, "replication": 1, "config": []},
+  {"topicName": "payments", "partitions": 1, "replication": 1, "tags": [{"name": "platform"}]},
+  {"topicName": "audit-log", "partitions": 1, "replication": 1, "tags": [{"name": "platform"}]},
+  {"topicName": "scratch", "partitions": 1, "replication": 1},
+  {"topicName": "__consumer_offsets", "partitions": 50, "replication": 1, "tags": [{"name": "platform"}]}
+]`
+
+func TestImportTopicsPrune(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-prune", map[string]string{
+		pkg.TopicsPath + "/orders.yaml": "name: orders\npartitions: 1\nreplication: 1\n",
+	})
+	defer remove()
+
+	var deleted []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			return
+		}
+		w.Write([]byte(pruneTopicsResp))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	deletions := func(output string) (names []string) {
+		var plan importPlan
+		assert.Nil(t, json.Unmarshal([]byte(output), &plan))
+		for _, change := range plan.Changes {
+			if change.Action == planDelete {
+				names = append(names, change.Name)
+			}
+		}
+		return
+	}
+
+	cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--prune")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"payments", "audit-log", "scratch"}, deletions(output))
+
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	output, err = test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--prune", "--prune-protect", "audit-*", "--prune-owner", "platform")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"payments"}, deletions(output))
+	assert.Empty(t, deleted)
+
+	// without a terminal the deletion cannot be confirmed.
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--prune", "--prune-owner", "platform")
+	assert.Error(t, err)
+	assert.Empty(t, deleted)
+
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--prune", "--prune-protect", "audit-*", "--prune-owner", "platform", "--yes")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/api/topics/payments"}, deleted)
+}
+
+func TestImportTopicsPruneIgnoreErrors(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-prune", map[string]string{
+		pkg.TopicsPath + "/orders.yaml":   "name: orders\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/payments.yaml": "name: [payments\n",
+	})
+	defer remove()
+
+	var deleted []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			return
+		}
+		w.Write([]byte(pruneTopicsResp))
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run", "--prune", "--ignore-errors")
+	assert.Nil(t, err)
+
+	var plan importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &plan))
+	assert.Equal(t, 1, plan.Summary.Invalid)
+	assert.Equal(t, 0, plan.Summary.Delete)
+
+	cmd = test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--prune", "--ignore-errors", "--yes")
+	assert.Nil(t, err)
+	assert.Empty(t, deleted)
+}
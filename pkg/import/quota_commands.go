@@ -9,7 +9,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
 	quotapkg "github.com/lensesio/lenses-go/pkg/quota"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,87 +24,115 @@ func NewImportQuotasCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.QuotasPath)
-			if err := loadQuotas(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.QuotasPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.QuotasPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planQuotas(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load quotas. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadQuotas(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planQuotas(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading quotas from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	lensesQuotas, err := client.GetQuotas()
 	var lensesReq []api.CreateQuotaPayload
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, lq := range lensesQuotas {
 		lensesReq = append(lensesReq, lq.GetQuotaAsRequest())
 	}
 
+	var (
+		changes   []planChange
+		described = make(map[string]bool)
+	)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
 		var quotas []api.CreateQuotaPayload
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &quotas); err != nil {
+		if err := load(opts, importFilePath, &quotas); err != nil {
+			if change, ok := invalidFile(cmd, "quota", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
 		for _, quota := range quotas {
+			quota := quota
 
-			found := false
+			var live interface{}
 			for _, lq := range lensesReq {
 				if quota.ClientID == lq.ClientID &&
 					quota.QuotaType == lq.QuotaType &&
-					quota.User == lq.User &&
-					quota.Config.ConsumerByteRate == quota.Config.ConsumerByteRate &&
-					quota.Config.ProducerByteRate == quota.Config.ProducerByteRate &&
-					quota.Config.RequestPercentage == quota.Config.RequestPercentage {
-					found = true
+					quota.User == lq.User {
+					live = lq
+					break
 				}
 			}
 
-			if found {
-				continue
-			}
-
-			if quota.QuotaType == string(api.QuotaEntityClient) ||
-				quota.QuotaType == string(api.QuotaEntityClients) ||
-				quota.QuotaType == string(api.QuotaEntityClientsDefault) {
-				if err := quotapkg.CreateQuotaForClients(cmd, client, quota); err != nil {
-					golog.Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
-						quota.QuotaType, quota.ClientID, quota.User, loadpath, err.Error())
-					return err
+			change, err := opts.newChange("quota", quotaName(quota), importFilePath, live, quota, func() error {
+				if quota.QuotaType == string(api.QuotaEntityClient) ||
+					quota.QuotaType == string(api.QuotaEntityClients) ||
+					quota.QuotaType == string(api.QuotaEntityClientsDefault) {
+					return quotapkg.CreateQuotaForClients(cmd, client, quota)
 				}
 
-				golog.Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
-					quota.QuotaType, quota.ClientID, quota.User, loadpath)
-				continue
-
+				return quotapkg.CreateQuotaForUsers(cmd, client, quota)
+			})
+			if err != nil {
+				return nil, err
 			}
 
-			if err := quotapkg.CreateQuotaForUsers(cmd, client, quota); err != nil {
-				golog.Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
-					quota.QuotaType, quota.ClientID, quota.User, loadpath, err.Error())
-				return err
-			}
+			changes = append(changes, change)
+			described[quotaName(quota)] = true
+		}
+	}
 
-			golog.Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
-				quota.QuotaType, quota.ClientID, quota.User, loadpath)
+	prune := pruneOptionsFrom(cmd)
+	for _, lq := range lensesReq {
+		lq := lq
+		if described[quotaName(lq)] {
+			continue
+		}
+
+		if change, ok := prune.deleteChange("quota", quotaName(lq), nil, func() error {
+			return quotapkg.DeleteQuota(client, lq)
+		}); ok {
+			changes = append(changes, change)
 		}
 	}
-	return nil
+
+	return changes, nil
+}
+
+func quotaName(quota api.CreateQuotaPayload) string {
+	return fmt.Sprintf("type %s, user %s, client %s", quota.QuotaType, quota.User, quota.ClientID)
 }
@@ -0,0 +1,142 @@
+package imports
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+//NewImportRollbackCommand creates `import rollback` command
+func NewImportRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <snapshot>",
+		Short: "Bring the resources touched by an import back to the snapshot taken before it",
+		Long: `Import the live state captured by the snapshot taken before an import, and delete the resources that import created.
+The snapshot is a directory of the --snapshot-dir, or its path.`,
+		Example: `
+import rollback 20201201-102030 --dry-run
+import rollback ~/.lenses/snapshots/20201201-102030 --yes`,
+		Args:             cobra.ExactArgs(1),
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshot, err := readSnapshot(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			if host := config.Client.Config.Host; snapshot.record.Host != "" && snapshot.record.Host != host {
+				return fmt.Errorf("snapshot [%s] was taken from [%s], not from the current context [%s]", snapshot.dir, snapshot.record.Host, host)
+			}
+
+			// the landscape files are loaded relative to the snapshot and its resources created by the import are pruned.
+			cmd.Flags().Set("dir", snapshot.dir)
+			cmd.Flags().Set(pruneFlagKey, "true")
+
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+
+			resources := rollbackResources(snapshot)
+			if dryRun, _ := cmd.Flags().GetBool(dryRunFlagKey); dryRun {
+				var changes []planChange
+				for _, resource := range resources {
+					resourceChanges, err := resource.plan(config.Client, cmd, opts, filepath.Join(snapshot.dir, resource.Path))
+					if err != nil {
+						return fmt.Errorf("failed to load %s. [%v]", resource.Name, err)
+					}
+					changes = append(changes, resourceChanges...)
+				}
+
+				return runPlan(cmd, changes)
+			}
+
+			results, err := importResources(config.Client, cmd, opts, snapshot.dir, resources, nil)
+			if len(results) > 0 {
+				if printErr := bite.PrintObject(cmd, results); printErr != nil {
+					return printErr
+				}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String("dir", "", "")
+	cmd.Flags().MarkHidden("dir")
+
+	canPlan(cmd)
+	canPrune(cmd)
+	for _, name := range []string{pruneFlagKey, pruneProtectFlagKey, pruneOwnerFlagKey, noSnapshotFlagKey} {
+		cmd.Flags().MarkHidden(name)
+	}
+	// the rollback is not snapshotted, the `--snapshot-dir` locates the snapshot to roll back to.
+	cmd.Flags().Set(noSnapshotFlagKey, "true")
+	cmd.Flags().Lookup(snapshotDirFlagKey).Usage = "Directory of the snapshots taken before every import"
+
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	cmd.Flags().Set("silent", "true")
+	return cmd
+}
+
+// rollbackResources returns the resource directories of a snapshot in import order, their plans keep
+// only the deletions of the resources created by the import the snapshot was taken for.
+func rollbackResources(snapshot *importSnapshot) []landscapeResource {
+	created := make(map[snapshotResource]bool, len(snapshot.record.Created))
+	for _, resource := range snapshot.record.Created {
+		created[resource] = true
+	}
+
+	captured := make(map[string]bool, len(snapshot.record.Resources))
+	for _, name := range snapshot.record.Resources {
+		captured[name] = true
+	}
+
+	var resources []landscapeResource
+	for _, resource := range landscapeResources("0s", 5) {
+		if !captured[resource.Name] {
+			continue
+		}
+
+		resource := resource
+		plan := resource.plan
+		resource.plan = func(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
+			changes, err := plan(client, cmd, opts, loadpath)
+			if err != nil {
+				return nil, err
+			}
+
+			kept := changes[:0]
+			deleted := make(map[snapshotResource]bool)
+			for _, change := range changes {
+				key := snapshotResource{Resource: resource.Name, Kind: change.Kind, Name: change.Name}
+				if change.Action == planDelete {
+					if !created[key] {
+						continue
+					}
+					deleted[key] = true
+				}
+				kept = append(kept, change)
+			}
+
+			for _, c := range snapshot.record.Created {
+				if c.Resource == resource.Name && !deleted[c] {
+					golog.Warnf("The %s [%s] created by the import cannot be deleted by the rollback, it may have been deleted already", c.Kind, c.Name)
+				}
+			}
+
+			return kept, nil
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources
+}
@@ -1,14 +1,17 @@
 package imports
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/kataras/golog"
 	"github.com/lensesio/bite"
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -17,49 +20,179 @@ func NewImportSchemasCommand() *cobra.Command {
 	var path string
 
 	cmd := &cobra.Command{
-		Use:              "schemas",
-		Short:            "schemas",
+		Use:   "schemas",
+		Short: "schemas",
+		Long: `Register the schemas of the landscape, a file per subject, or the .avsc files mapped to their subjects
+by the ` + api.SchemaSubjectsFileName + ` file of the schemas directory, see ` + "`export schemas --avsc`" + `.`,
 		Example:          `import schemas --landscape /my-landscape --ignore-errors`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.SchemasPath)
-			if err := loadSchemas(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.SchemasPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.SchemasPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planSchemas(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load schemas. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadSchemas(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planSchemas(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading schemas from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
+	subjects, err := client.GetSubjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		changes []planChange
+		schemas []schemaFile
+	)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
+		if isSubjectsFile(file.Name()) {
+			subjectSchemas, subjectChanges, err := loadSubjects(cmd, opts, loadpath, importFilePath)
+			if err != nil {
+				return nil, err
+			}
+			schemas = append(schemas, subjectSchemas...)
+			changes = append(changes, subjectChanges...)
+			continue
+		}
+
+		// the .avsc files are registered by the subjects file mapping them.
+		if filepath.Ext(file.Name()) == ".avsc" {
+			golog.Warnf("Skipping [%s], the .avsc files are imported through the subjects of the [%s] file", importFilePath, api.SchemaSubjectsFileName)
+			continue
+		}
+
 		var schema api.SchemaAsRequest
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &schema); err != nil {
+		if err := load(opts, importFilePath, &schema); err != nil {
+			if change, ok := invalidFile(cmd, "schema", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			return nil, err
+		}
+
+		schemas = append(schemas, schemaFile{schema: schema, file: importFilePath})
+	}
+
+	for _, s := range schemas {
+		schema := s.schema
+
+		var live interface{}
+		for _, subject := range subjects {
+			if subject != schema.Name {
+				continue
+			}
+
+			latest, err := client.GetLatestSchema(subject)
+			if err != nil {
+				golog.Errorf("Error retrieving the latest schema of [%s]. [%s]", subject, err.Error())
+				return nil, err
+			}
+
+			live = schemaState(client.GetSchemaAsRequest(latest))
+			break
+		}
+
+		change, err := opts.newChange("schema", schema.Name, s.file, live, schemaState(schema), func() error {
+			_, err := client.RegisterSchema(schema.Name, schema.AvroSchema)
 			return err
+		})
+		if err != nil {
+			return nil, err
 		}
 
-		_, err := client.RegisterSchema(schema.Name, schema.AvroSchema)
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// schemaFile is the schema of a subject and the file it is loaded from.
+type schemaFile struct {
+	schema api.SchemaAsRequest
+	file   string
+}
+
+// isSubjectsFile reports whether a file of the schemas directory maps the subjects to their .avsc files.
+func isSubjectsFile(name string) bool {
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) == api.SchemaSubjectsFileName && (ext == ".yaml" || ext == ".yml" || ext == ".json")
+}
+
+// loadSubjects loads the schemas of the subjects of a subjects file from their .avsc files,
+// their paths are relative to the schemas directory.
+func loadSubjects(cmd *cobra.Command, opts overlayOptions, loadpath, subjectsFile string) ([]schemaFile, []planChange, error) {
+	var mapping api.SchemaSubjectsFile
+	if err := load(opts, subjectsFile, &mapping); err != nil {
+		if change, ok := invalidFile(cmd, "schema", subjectsFile, err); ok {
+			return nil, []planChange{change}, nil
+		}
+		return nil, nil, err
+	}
+
+	var (
+		schemas []schemaFile
+		changes []planChange
+	)
+	for _, subject := range mapping.Subjects {
+		avscFile := filepath.Join(loadpath, filepath.FromSlash(subject.File))
+
+		err := fmt.Errorf("subject [%s] has no .avsc file", subject.Subject)
+		var avroSchema string
+		if subject.Subject == "" {
+			err = fmt.Errorf("an entry of [%s] has no subject", subjectsFile)
+		} else if subject.File != "" {
+			avroSchema, err = opts.renderText(avscFile)
+		}
 
 		if err != nil {
-			golog.Errorf("Error creating schema from file [%s]. [%s]", loadpath, err.Error())
-			return err
+			if change, ok := invalidFile(cmd, "schema", avscFile, err); ok {
+				changes = append(changes, change)
+				continue
+			}
+			return nil, nil, err
 		}
 
-		golog.Infof("Created schema from [%s]", loadpath)
+		schema := api.SchemaAsRequest{Name: subject.Subject, AvroSchema: strings.TrimSpace(avroSchema)}
+		schemas = append(schemas, schemaFile{schema: schema, file: avscFile})
+	}
+
+	return schemas, changes, nil
+}
+
+// schemaState returns the schema with its Avro schema compacted, so that only a different schema registers a new version.
+func schemaState(schema api.SchemaAsRequest) api.SchemaAsRequest {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(schema.AvroSchema)); err == nil {
+		schema.AvroSchema = compacted.String()
 	}
 
-	return nil
+	return schema
 }
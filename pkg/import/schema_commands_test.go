@@ -0,0 +1,72 @@
+package imports
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSchemasAvsc(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-schemas", nil)
+	defer remove()
+
+	order := "{\n  \"type\": \"record\",\n  \"name\": \"Order\",\n  \"namespace\": \"com.acme\",\n  \"fields\": [{\"name\": \"id\", \"type\": \"string\"}]\n}\n"
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.SchemasPath + "/subjects.yaml": `subjects:
+- subject: orders-value
+  version: 1
+  file: avro/com/acme/Order.avsc
+- subject: refunds-value
+  file: avro/com/acme/Order.avsc
+`,
+		pkg.SchemasPath + "/avro/com/acme/Order.avsc": order,
+		pkg.SchemasPath + "/schema-orders-key.yaml":   "name: orders-key\navroSchema: '\"string\"'\n",
+	})
+
+	var registered []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			var payload struct {
+				Schema string `json:"schema"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			registered = append(registered, r.URL.Path+" "+payload.Schema)
+			w.Write([]byte(`{"id": 2}`))
+		case r.URL.Path == "/api/proxy-sr/subjects":
+			w.Write([]byte(`["orders-value"]`))
+		default:
+			w.Write([]byte(`{"subject": "orders-value", "version": 1, "id": 1, "schema": "{\"type\":\"record\",\"name\":\"Order\",\"namespace\":\"com.acme\",\"fields\":[{\"name\":\"id\",\"type\":\"string\"}]}"}`))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportSchemasCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--dir", dir, "--dry-run")
+	assert.Nil(t, err)
+
+	var p importPlan
+	assert.Nil(t, json.Unmarshal([]byte(output), &p))
+	changes := make(map[string]planChange)
+	for _, change := range p.Changes {
+		changes[change.Name] = change
+	}
+
+	assert.Len(t, changes, 3)
+	// the pretty-printed .avsc file is the live schema.
+	assert.Equal(t, planNoop, changes["orders-value"].Action)
+	assert.Equal(t, planCreate, changes["refunds-value"].Action)
+	assert.Equal(t, dir+"/"+pkg.SchemasPath+"/avro/com/acme/Order.avsc", changes["refunds-value"].File)
+	assert.Equal(t, planCreate, changes["orders-key"].Action)
+
+	cmd = test.WithOutput(NewImportSchemasCommand(), "json")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--no-snapshot")
+	assert.Nil(t, err)
+	assert.Len(t, registered, 2)
+	assert.Contains(t, registered, "/api/proxy-sr/subjects/refunds-value/versions "+order[:len(order)-1])
+}
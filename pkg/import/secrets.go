@@ -0,0 +1,191 @@
+package imports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lensesio/lenses-go/pkg/secret"
+)
+
+// secretReference is a `${provider:reference}` secret placeholder of a landscape file.
+type secretReference struct {
+	provider  string
+	reference string
+}
+
+func parseSecretReference(placeholder string) (secretReference, bool) {
+	if !strings.HasPrefix(placeholder, "${") || !strings.HasSuffix(placeholder, "}") {
+		return secretReference{}, false
+	}
+
+	kv := strings.SplitN(placeholder[2:len(placeholder)-1], ":", 2)
+	if len(kv) != 2 || !secret.IsReferenceProvider(kv[0]) || kv[1] == "" {
+		return secretReference{}, false
+	}
+
+	return secretReference{provider: kv[0], reference: kv[1]}, true
+}
+
+// secretResolver is shared by the loads of a command so the Vault and Key Vault clients are created once.
+var secretResolver = secret.NewResolver()
+
+const (
+	redactedSecret = "********"
+	// minRedactedSecretLength is the length of the shortest secret value redacted from the plans and the drifts,
+	// shorter ones, e.g. a port or "true", would redact the same values of unrelated fields.
+	minRedactedSecretLength = 6
+)
+
+// resolvedSecrets are the values of the secrets resolved by the loads of a command, redacted from its plans and drifts.
+type resolvedSecrets struct {
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+func newResolvedSecrets() *resolvedSecrets {
+	return &resolvedSecrets{values: make(map[string]struct{})}
+}
+
+func (r *resolvedSecrets) add(value string) {
+	if len(value) < minRedactedSecretLength {
+		return
+	}
+
+	r.mu.Lock()
+	r.values[value] = struct{}{}
+	r.mu.Unlock()
+}
+
+// resolveSecrets replaces the tokens of the secret placeholders of a rendered landscape file with the values of the secrets.
+// The file is decoded first so a value is written back quoted and escaped as its format requires.
+func (o overlayOptions) resolveSecrets(file string, b []byte) ([]byte, error) {
+	if o.offline {
+		return b, nil
+	}
+
+	found := false
+	for token := range o.secrets {
+		if strings.Contains(string(b), token) {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return b, nil
+	}
+
+	var doc interface{}
+	if err := unmarshalByExt(file, b, &doc); err != nil {
+		return nil, err
+	}
+
+	doc, err := o.resolveSecretValues(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the secrets of [%s]. [%v]", file, err)
+	}
+
+	return marshalByExt(file, doc)
+}
+
+func (o overlayOptions) resolveSecretValues(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case string:
+		return o.resolveSecretString(value)
+	case map[string]interface{}:
+		for k, item := range value {
+			resolved, err := o.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			value[k] = resolved
+		}
+	case map[interface{}]interface{}:
+		for k, item := range value {
+			resolved, err := o.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			value[k] = resolved
+		}
+	case []interface{}:
+		for i, item := range value {
+			resolved, err := o.resolveSecretValues(item)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = resolved
+		}
+	}
+
+	return v, nil
+}
+
+func (o overlayOptions) resolveSecretString(s string) (string, error) {
+	if o.offline {
+		return s, nil
+	}
+
+	tokens := make([]string, 0, len(o.secrets))
+	for token := range o.secrets {
+		if strings.Contains(s, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	// "__secret_1__" must not be replaced inside "__secret_11__".
+	sort.Slice(tokens, func(i, j int) bool { return len(tokens[i]) > len(tokens[j]) })
+
+	for _, token := range tokens {
+		ref := o.secrets[token]
+		value, err := secretResolver.Resolve(ref.provider, ref.reference)
+		if err != nil {
+			return "", fmt.Errorf("secret [${%s:%s}]. [%v]", ref.provider, ref.reference, err)
+		}
+
+		o.resolved.add(value)
+
+		s = strings.ReplaceAll(s, token, value)
+	}
+
+	return s, nil
+}
+
+// redact replaces the values of the resolved secrets in the strings of a generic value,
+// so plans and drifts do not print them.
+func (r *resolvedSecrets) redact(v interface{}) interface{} {
+	if r == nil {
+		return v
+	}
+
+	switch value := v.(type) {
+	case string:
+		return r.redactString(value)
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			redacted[k] = r.redact(item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, item := range value {
+			redacted[i] = r.redact(item)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+func (r *resolvedSecrets) redactString(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for value := range r.values {
+		s = strings.ReplaceAll(s, value, redactedSecret)
+	}
+
+	return s
+}
@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadResolvesSecrets(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-secrets", nil)
+	defer remove()
+
+	os.Setenv("LENSES_TEST_DB_PASSWORD", `p@ss: "w#rd"`)
+	defer os.Unsetenv("LENSES_TEST_DB_PASSWORD")
+
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.ConnectorsPath + "/connector-sink.yaml": `name: sink
+clusterName: dev
+config:
+  connection.password: ${env:LENSES_TEST_DB_PASSWORD}
+  connection.url: jdbc:postgresql://db/${database}?password=${env:LENSES_TEST_DB_PASSWORD}
+  connection.user: $${env:USER}
+  ssl.key.password: ${file:/secrets.properties:ssl}
+`,
+		pkg.ConnectorsPath + "/connector-missing.yaml": "name: missing\nclusterName: dev\nconfig:\n  password: ${env:LENSES_TEST_UNSET}\n",
+	})
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dir", dir, "")
+	canOverlay(cmd)
+	assert.Nil(t, cmd.Flags().Set(setFlagKey, "database=orders"))
+	opts, err := overlayOptionsFrom(cmd)
+	assert.Nil(t, err)
+
+	var connector api.CreateUpdateConnectorPayload
+	assert.Nil(t, load(opts, filepath.Join(dir, pkg.ConnectorsPath, "connector-sink.yaml"), &connector))
+	assert.Equal(t, `p@ss: "w#rd"`, connector.Config["connection.password"])
+	assert.Equal(t, `jdbc:postgresql://db/orders?password=p@ss: "w#rd"`, connector.Config["connection.url"])
+	assert.Equal(t, "${env:USER}", connector.Config["connection.user"])
+	assert.Equal(t, "${file:/secrets.properties:ssl}", connector.Config["ssl.key.password"])
+
+	// resolved secrets are not printed by the plans.
+	change, err := opts.newChange("connector", "sink", "", api.CreateUpdateConnectorPayload{Name: "sink", Config: api.ConnectorConfig{}}, connector, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, planUpdate, change.Action)
+	for _, d := range change.Diff {
+		assert.NotContains(t, formatPlanValue(d.Desired), "w#rd")
+	}
+	assert.Equal(t, `jdbc:postgresql://db/orders?password=********`, opts.resolved.redact(connector.Config["connection.url"]))
+
+	// short values are not redacted from unrelated fields and the secrets of a command are not redacted by another.
+	os.Setenv("LENSES_TEST_DB_PORT", "5432")
+	defer os.Unsetenv("LENSES_TEST_DB_PORT")
+	test.WriteLandscapeFiles(t, dir, map[string]string{
+		pkg.ConnectorsPath + "/connector-port.yaml": "name: port\nclusterName: dev\nconfig:\n  port: ${env:LENSES_TEST_DB_PORT}\n",
+	})
+	assert.Nil(t, load(opts, filepath.Join(dir, pkg.ConnectorsPath, "connector-port.yaml"), &connector))
+	assert.Equal(t, "batch.size=5432", opts.resolved.redact("batch.size=5432"))
+
+	other, err := overlayOptionsFrom(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, `p@ss: "w#rd"`, other.resolved.redact(`p@ss: "w#rd"`))
+
+	err = load(opts, filepath.Join(dir, pkg.ConnectorsPath, "connector-missing.yaml"), &connector)
+	assert.EqualError(t, err, "unable to resolve the secrets of ["+filepath.Join(dir, pkg.ConnectorsPath, "connector-missing.yaml")+
+		"]. [secret [${env:LENSES_TEST_UNSET}]. [environment variable [LENSES_TEST_UNSET] is not set]]")
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/lensesio/lenses-go/pkg"
 	"github.com/lensesio/lenses-go/pkg/api"
 	config "github.com/lensesio/lenses-go/pkg/configs"
-	"github.com/lensesio/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -29,69 +28,102 @@ func NewImportServiceAccountsCommand() *cobra.Command {
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.ServiceAccountsPath)
-			if err := loadServiceAccounts(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load user groups. [%s]", err.Error())
+			checkManifest(config.Client, path, pkg.ServiceAccountsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.ServiceAccountsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
 				return err
 			}
-			return nil
+			changes, err := planServiceAccounts(config.Client, cmd, opts, loadpath)
+			if err != nil {
+				golog.Errorf("Failed to load service accounts. [%s]", err.Error())
+				return err
+			}
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func loadServiceAccounts(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planServiceAccounts(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading service accounts from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 
 	currentSvcAccs, err := client.GetServiceAccounts()
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var changes []planChange
+	described := make(map[string]bool)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
 
 		var svcacc api.ServiceAccount
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &svcacc); err != nil {
+		if err := load(opts, importFilePath, &svcacc); err != nil {
+			if change, ok := invalidFile(cmd, "service account", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
-		found := false
-		for _, sva := range currentSvcAccs {
-			if sva.Name == svcacc.Name {
-				found = true
-
-				payload := &api.ServiceAccount{
-					Name:   svcacc.Name,
-					Owner:  svcacc.Owner,
-					Groups: svcacc.Groups,
+		var (
+			live  interface{}
+			apply = func() error {
+				payload, err := client.CreateServiceAccount(&svcacc)
+				if err != nil {
+					return err
 				}
+				golog.Infof("Created service account [%s], Token:[%s]", svcacc.Name, payload.Token)
+				return nil
+			}
+		)
 
-				if err := config.Client.UpdateServiceAccount(payload); err != nil {
-					golog.Errorf("Error updating service account [%s]. [%s]", svcacc.Name, err.Error())
-					return err
+		for _, sva := range currentSvcAccs {
+			if sva.Name == svcacc.Name {
+				live = sva
+				apply = func() error {
+					return client.UpdateServiceAccount(&svcacc)
 				}
-				golog.Infof("Updated service account [%s]", svcacc.Name)
+				break
 			}
 		}
 
-		if found {
+		change, err := opts.newChange("service account", svcacc.Name, importFilePath, live, svcacc, apply)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+		described[svcacc.Name] = true
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	for _, sva := range currentSvcAccs {
+		if described[sva.Name] {
 			continue
 		}
 
-		payload, err := client.CreateServiceAccount(&svcacc)
-		if err != nil {
-			golog.Errorf("Error creating service account [%s] from [%s] [%s]", svcacc.Name, loadpath, err.Error())
-			return err
+		name := sva.Name
+		if change, ok := prune.deleteChange("service account", name, []string{sva.Owner}, func() error {
+			return client.DeleteServiceAccount(name)
+		}); ok {
+			changes = append(changes, change)
 		}
-		golog.Infof("Created service account [%s], Token:[%s]", svcacc.Name, payload.Token)
 	}
 
-	return nil
+	return changes, nil
 }
@@ -0,0 +1,226 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/export"
+	"github.com/spf13/cobra"
+)
+
+const (
+	snapshotDirFlagKey = "snapshot-dir"
+	noSnapshotFlagKey  = "no-snapshot"
+
+	// snapshotFileName is the name of the record of a snapshot, at the root of its directory.
+	snapshotFileName = "snapshot.json"
+
+	// the snapshots hold unmasked secrets, e.g. the passwords of the connections, only their owner can read them.
+	snapshotDirMode  os.FileMode = 0700
+	snapshotFileMode os.FileMode = 0600
+)
+
+// defaultSnapshotDir is where the snapshots are taken unless `--snapshot-dir` is set.
+var defaultSnapshotDir = filepath.Join(api.DefaultConfigurationHomeDir, "snapshots")
+
+// canSnapshot registers the `--snapshot-dir` and `--no-snapshot` flags to an import command.
+func canSnapshot(cmd *cobra.Command) {
+	cmd.Flags().String(snapshotDirFlagKey, defaultSnapshotDir, "Directory of the snapshots of the live state taken before every import, see `import rollback`. "+
+		"The snapshots contain the secrets of the live state unmasked, their files are readable by their owner only")
+	cmd.Flags().Bool(noSnapshotFlagKey, false, "Import without taking a snapshot of the live state first, the import cannot be rolled back. "+
+		"Use it to keep the secrets of the live state, e.g. connection passwords, off the disk")
+}
+
+type (
+	// snapshotResource is a resource created by the import which took a snapshot,
+	// "Resource" is the name of its resource directory, e.g. "topics".
+	snapshotResource struct {
+		Resource string `json:"resource" yaml:"resource"`
+		Kind     string `json:"kind" yaml:"kind"`
+		Name     string `json:"name" yaml:"name"`
+	}
+
+	// snapshotRecord describes a snapshot, "Resources" are the names of the resource directories it captured
+	// and "Created" the resources the import created, they are deleted on rollback.
+	snapshotRecord struct {
+		Host      string             `json:"host" yaml:"host"`
+		TakenAt   time.Time          `json:"takenAt" yaml:"takenAt"`
+		Landscape string             `json:"landscape" yaml:"landscape"`
+		Resources []string           `json:"resources" yaml:"resources"`
+		Created   []snapshotResource `json:"created" yaml:"created"`
+	}
+
+	// importSnapshot is the live state of the resources an import touches, exported to a landscape directory
+	// before the import applies its changes. A nil snapshot records nothing.
+	importSnapshot struct {
+		dir    string
+		record snapshotRecord
+	}
+)
+
+// takeSnapshot exports the live state of the "resources", named after their resource directories, e.g. "topics",
+// to a new timestamped directory of the `--snapshot-dir`. It returns nil if `--no-snapshot` is set or none of the resources can be captured.
+func takeSnapshot(client *api.Client, cmd *cobra.Command, landscape string, resources []string) (*importSnapshot, error) {
+	if skip, _ := cmd.Flags().GetBool(noSnapshotFlagKey); skip {
+		return nil, nil
+	}
+
+	supported := make(map[string]bool)
+	for _, name := range export.SnapshotResources() {
+		supported[name] = true
+	}
+
+	paths := make(map[string]string)
+	for _, resource := range landscapeResources("0s", 1) {
+		paths[resource.Name] = resource.Path
+	}
+
+	var names []string
+	for _, name := range resources {
+		if !supported[name] {
+			golog.Warnf("The live state of [%s] cannot be snapshotted, its import cannot be rolled back", name)
+			continue
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	root, _ := cmd.Flags().GetString(snapshotDirFlagKey)
+	if root == "" {
+		root = defaultSnapshotDir
+	}
+
+	now := time.Now().UTC()
+	dir, err := newSnapshotDir(root, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the snapshot directory in [%s]. [%v]", root, err)
+	}
+
+	if err = export.Snapshot(client, dir, names); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("%v, set --%s to import without a snapshot", err, noSnapshotFlagKey)
+	}
+
+	// the resources without live state have an empty directory, the ones the import creates are deleted on rollback.
+	for _, name := range names {
+		if err = os.MkdirAll(filepath.Join(dir, paths[name]), snapshotDirMode); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := &importSnapshot{dir: dir, record: snapshotRecord{
+		Host:      client.Config.Host,
+		TakenAt:   now,
+		Landscape: landscape,
+		Resources: names,
+		Created:   []snapshotResource{},
+	}}
+
+	if err = snapshot.write(); err != nil {
+		return nil, err
+	}
+
+	golog.Infof("Snapshot of the live state of [%d] resource directories taken at [%s]", len(names), dir)
+	return snapshot, nil
+}
+
+// newSnapshotDir creates the directory of a snapshot taken at "now", named after its time.
+func newSnapshotDir(root string, now time.Time) (string, error) {
+	if err := os.MkdirAll(root, snapshotDirMode); err != nil {
+		return "", err
+	}
+
+	name := now.Format("20060102-150405")
+	for i := 2; ; i++ {
+		dir := filepath.Join(root, name)
+		err := os.Mkdir(dir, snapshotDirMode)
+		if err == nil {
+			return dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", err
+		}
+		name = fmt.Sprintf("%s-%d", now.Format("20060102-150405"), i)
+	}
+}
+
+func (s *importSnapshot) write() error {
+	b, err := json.MarshalIndent(s.record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(s.dir, snapshotFileName), b, snapshotFileMode); err != nil {
+		return fmt.Errorf("failed to write the snapshot record [%s]. [%v]", filepath.Join(s.dir, snapshotFileName), err)
+	}
+
+	return nil
+}
+
+// recordCreated records the resources the changes of a resource directory create, before they are applied.
+func (s *importSnapshot) recordCreated(resource string, changes []planChange) error {
+	if s == nil {
+		return nil
+	}
+
+	found := false
+	for _, name := range s.record.Resources {
+		found = found || name == resource
+	}
+	if !found {
+		return nil
+	}
+
+	for _, change := range changes {
+		if change.Action == planCreate {
+			s.record.Created = append(s.record.Created, snapshotResource{Resource: resource, Kind: change.Kind, Name: change.Name})
+		}
+	}
+
+	return s.write()
+}
+
+// warnRollback tells how to roll back an import which failed after taking the snapshot.
+func (s *importSnapshot) warnRollback() {
+	if s == nil {
+		return
+	}
+
+	golog.Warnf("The import failed, run `import rollback %s` to bring the resources back to their state before the import", s.dir)
+}
+
+// readSnapshot reads the record of a snapshot, "name" is its directory or the name of its directory in the `--snapshot-dir`.
+func readSnapshot(cmd *cobra.Command, name string) (*importSnapshot, error) {
+	dir := name
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+		root, _ := cmd.Flags().GetString(snapshotDirFlagKey)
+		if root == "" {
+			root = defaultSnapshotDir
+		}
+		dir = filepath.Join(root, name)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, snapshotFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot [%s] not found", name)
+		}
+		return nil, err
+	}
+
+	snapshot := &importSnapshot{dir: dir}
+	if err = json.Unmarshal(b, &snapshot.record); err != nil {
+		return nil, fmt.Errorf("invalid snapshot record [%s]. [%v]", filepath.Join(dir, snapshotFileName), err)
+	}
+
+	return snapshot, nil
+}
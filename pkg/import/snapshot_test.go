@@ -0,0 +1,154 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	// the imports of the tests take their snapshots out of the home directory.
+	dir, err := ioutil.TempDir("", "import-snapshots")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defaultSnapshotDir = dir
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestImportRollback(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "import-rollback", nil)
+	defer remove()
+
+	landscape := filepath.Join(dir, "landscape")
+	snapshots := filepath.Join(dir, "snapshots")
+	test.WriteLandscapeFiles(t, landscape, map[string]string{
+		pkg.TopicsPath + "/topic-orders.yaml":   "name: orders\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-payments.yaml": "name: payments\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-refunds.yaml":  "name: refunds\npartitions: 1\nreplication: 1\n",
+		pkg.TopicsPath + "/topic-zbad.yaml":     "name: zbad\npartitions: 1\nreplication: 1\n",
+	})
+
+	var (
+		mu     sync.Mutex
+		topics = map[string]bool{"orders": true}
+	)
+	liveTopics := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		var names []string
+		for name := range topics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			var live []string
+			for name := range topics {
+				live = append(live, fmt.Sprintf(`{"topicName": %q, "partitions": 1, "replication": 1, "config": []}`, name))
+			}
+			w.Write([]byte("[" + strings.Join(live, ",") + "]"))
+		case http.MethodPost:
+			var payload api.CreateTopicPayload
+			json.NewDecoder(r.Body).Decode(&payload)
+			if payload.TopicName == "zbad" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`invalid topic`))
+				return
+			}
+			topics[payload.TopicName] = true
+		case http.MethodDelete:
+			delete(topics, strings.TrimPrefix(r.URL.Path, "/api/topics/"))
+		}
+	})
+	_, teardown := test.UsingTestingClient(t, h)
+	defer teardown()
+
+	cmd := test.WithOutput(NewImportTopicsCommand(), "json")
+	_, err := test.ExecuteCommand(cmd, "--dir", landscape, "--snapshot-dir", snapshots)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"orders", "payments", "refunds"}, liveTopics())
+
+	entries, err := ioutil.ReadDir(snapshots)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	snapshot := entries[0].Name()
+
+	b, err := ioutil.ReadFile(filepath.Join(snapshots, snapshot, snapshotFileName))
+	assert.Nil(t, err)
+	var record snapshotRecord
+	assert.Nil(t, json.Unmarshal(b, &record))
+	assert.Equal(t, landscape, record.Landscape)
+	assert.Equal(t, []string{"topics"}, record.Resources)
+	assert.Equal(t, []snapshotResource{
+		{Resource: "topics", Kind: "topic", Name: "payments"},
+		{Resource: "topics", Kind: "topic", Name: "refunds"},
+		{Resource: "topics", Kind: "topic", Name: "zbad"},
+	}, record.Created)
+
+	// the snapshot holds unmasked secrets, only its owner can read it.
+	for _, path := range []string{snapshot, filepath.Join(snapshot, pkg.TopicsPath)} {
+		info, err := os.Stat(filepath.Join(snapshots, path))
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0700), info.Mode().Perm(), path)
+	}
+	for _, path := range []string{snapshotFileName, filepath.Join(pkg.TopicsPath, "topic-orders.yaml")} {
+		info, err := os.Stat(filepath.Join(snapshots, snapshot, path))
+		assert.Nil(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), path)
+	}
+
+	// a topic created since the snapshot, but not by the import, is left alone.
+	mu.Lock()
+	topics["audit"] = true
+	mu.Unlock()
+
+	rollback := func(args ...string) (importPlan, error) {
+		group := test.WithOutput(NewImportGroupCommand(), "json")
+		output, err := test.ExecuteCommand(group, append([]string{"rollback", snapshot, "--snapshot-dir", snapshots}, args...)...)
+		var p importPlan
+		if err == nil && len(args) > 0 && args[0] == "--dry-run" {
+			assert.Nil(t, json.Unmarshal([]byte(output), &p))
+		}
+		return p, err
+	}
+
+	p, err := rollback("--dry-run")
+	assert.Nil(t, err)
+	actions := make(map[string]planAction)
+	for _, change := range p.Changes {
+		actions[change.Name] = change.Action
+	}
+	assert.Equal(t, map[string]planAction{"orders": planNoop, "payments": planDelete, "refunds": planDelete}, actions)
+
+	_, err = rollback("--yes")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"audit", "orders"}, liveTopics())
+
+	group := test.WithOutput(NewImportGroupCommand(), "json")
+	_, err = test.ExecuteCommand(group, "rollback", "missing", "--snapshot-dir", snapshots)
+	assert.EqualError(t, err, "snapshot [missing] not found")
+}
@@ -19,73 +19,139 @@ func NewImportTopicsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:              "topics",
 		Short:            "topics",
-		Example:          `import topics --landscape /my-landscape --ignore-errors`,
+		Example:          `import topics --dir /my-landscape --concurrency 10 --ignore-errors`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			path = fmt.Sprintf("%s/%s", path, pkg.TopicsPath)
-			if err := loadTopics(config.Client, cmd, path); err != nil {
+			checkManifest(config.Client, path, pkg.TopicsPath)
+			loadpath := fmt.Sprintf("%s/%s", path, pkg.TopicsPath)
+			opts, err := overlayOptionsFrom(cmd)
+			if err != nil {
+				return err
+			}
+			changes, err := planTopics(config.Client, cmd, opts, loadpath)
+			if err != nil {
 				golog.Errorf("Failed to load topics. [%s]", err.Error())
 				return err
 			}
-			return nil
+			return runPlan(cmd, changes)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
 
+	canPlan(cmd)
+	canOverlay(cmd)
+	canPrune(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	cmd.Flags().Set("silent", "true")
 	return cmd
 }
 
-func loadTopics(client *api.Client, cmd *cobra.Command, loadpath string) error {
+func planTopics(client *api.Client, cmd *cobra.Command, opts overlayOptions, loadpath string) ([]planChange, error) {
 	golog.Infof("Loading topics from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	files, err := landscapeFiles(opts, loadpath)
+	if err != nil {
+		return nil, err
+	}
 	topics, err := client.GetTopics()
 
 	if err != nil {
 		golog.Errorf("Error retrieving topics [%s]", err.Error())
-		return err
+		return nil, err
 	}
 
+	var changes []planChange
+	described := make(map[string]bool)
 	for _, file := range files {
+		importFilePath := fmt.Sprintf("%s/%s", loadpath, file.Name())
+
 		var topic api.CreateTopicPayload
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &topic); err != nil {
+		if err := load(opts, importFilePath, &topic); err != nil {
+			if change, ok := invalidFile(cmd, "topic", importFilePath, err); ok {
+				changes = append(changes, change)
+				continue
+			}
 			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+			return nil, err
 		}
 
-		found := false
+		var (
+			live  interface{}
+			apply = func() error {
+				return client.CreateTopic(topic.TopicName, topic.Replication, topic.Partitions, topic.Configs)
+			}
+		)
 
 		for _, lensesTopic := range topics {
-			if lensesTopic.TopicName == topic.TopicName {
-				found = true
-				// If the number of partitions remain the same then reset to 0
-				// so that PUT operation is not triggered within 'UpdateTopic' function
-				if lensesTopic.Partitions == topic.Partitions {
-					topic.Partitions = 0
-				}
-				if err := client.UpdateTopic(topic.TopicName, []api.KV{topic.Configs}, topic.Partitions); err != nil {
-					golog.Errorf("Error updating topic [%s]. [%s]", topic.TopicName, err.Error())
-					return err
+			if lensesTopic.TopicName != topic.TopicName {
+				continue
+			}
+
+			if lensesTopic.Replication != topic.Replication {
+				golog.Warnf("Replication of topic [%s] is [%d] and it cannot be changed to [%d]", topic.TopicName, lensesTopic.Replication, topic.Replication)
+			}
+
+			// Only the partitions and the configs of the file can be updated,
+			// the rest of the live overrides are left untouched.
+			overrides := lensesTopic.ConfigOverrides()
+			configs := make(api.KV, len(topic.Configs))
+			for key := range topic.Configs {
+				if value, ok := overrides[key]; ok {
+					configs[key] = value
 				}
+			}
 
-				golog.Infof("Updated topic [%s]", topic.TopicName)
+			live = api.CreateTopicPayload{
+				TopicName:   lensesTopic.TopicName,
+				Replication: topic.Replication,
+				Partitions:  lensesTopic.Partitions,
+				Description: topic.Description,
+				Configs:     configs,
 			}
-		}
 
-		if !found {
-			if err := client.CreateTopic(topic.TopicName, topic.Replication, topic.Partitions, topic.Configs); err != nil {
-				golog.Errorf("Error creating topic [%s]. [%s]", topic.TopicName, err.Error())
-				return err
+			partitions := topic.Partitions
+			// If the number of partitions remain the same then reset to 0
+			// so that PUT operation is not triggered within 'UpdateTopic' function
+			if lensesTopic.Partitions == partitions {
+				partitions = 0
+			}
+
+			apply = func() error {
+				return client.UpdateTopic(topic.TopicName, []api.KV{topic.Configs}, partitions)
 			}
+			break
+		}
+
+		change, err := opts.newChange("topic", topic.TopicName, importFilePath, live, topic, apply)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+		described[topic.TopicName] = true
+	}
+
+	prune := pruneOptionsFrom(cmd)
+	for _, lensesTopic := range topics {
+		if described[lensesTopic.TopicName] || lensesTopic.IsControlTopic || lensesTopic.IsMarkedForDeletion || utils.IsSystemTopic(lensesTopic.TopicName) {
+			continue
+		}
+
+		var tags []string
+		for _, tag := range lensesTopic.Tags {
+			tags = append(tags, tag.Name)
+		}
 
-			golog.Infof("Created topic [%s]", topic.TopicName)
+		topicName := lensesTopic.TopicName
+		if change, ok := prune.deleteChange("topic", topicName, tags, func() error {
+			return client.DeleteTopic(topicName)
+		}); ok {
+			changes = append(changes, change)
 		}
 	}
 
-	return nil
+	return changes, nil
 }
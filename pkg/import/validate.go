@@ -0,0 +1,495 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrLandscapeInvalid is returned by `landscape validate` when a landscape file has problems.
+var ErrLandscapeInvalid = errors.New("the landscape has invalid files")
+
+// FileProblem is a problem of a landscape file found by ValidateLandscape.
+type FileProblem struct {
+	File    string `json:"file" yaml:"file"`
+	Problem string `json:"problem" yaml:"problem"`
+}
+
+// landscapeFileKind is a kind of the files of a resource directory, "value" returns a pointer to the value a file decodes to
+// and "check" the semantic problems of the decoded value.
+type landscapeFileKind struct {
+	Kind string
+	Path string
+	// match selects the files of the resource directory of this kind, "names" are all the files of the directory.
+	// A nil match selects the files no other kind of the directory selects.
+	match func(name string, names map[string]bool) bool
+	value func() interface{}
+	check func(v interface{}) []string
+}
+
+// landscapeFileKinds are the kinds of the landscape files, the import loads the files of a resource directory
+// which none of its kinds selects as plain text, e.g. the `.sql` files of the processors, or not at all.
+var landscapeFileKinds = []landscapeFileKind{
+	{Kind: "Connection", Path: pkg.ConnectionsFilePath, value: func() interface{} { return new(api.Connection) }, check: func(v interface{}) []string {
+		return requireName(v.(*api.Connection).Name)
+	}},
+	{Kind: "AlertChannel", Path: pkg.AlertChannelsFilePath, value: func() interface{} { return new(api.ChannelPayload) }, check: checkChannel},
+	{Kind: "AuditChannel", Path: pkg.AuditChannelsFilePath, value: func() interface{} { return new(api.ChannelPayload) }, check: checkChannel},
+	{Kind: "Topic", Path: pkg.TopicsPath, value: func() interface{} { return new(api.CreateTopicPayload) }, check: checkTopic},
+	{Kind: "SchemaSubjects", Path: pkg.SchemasPath, match: func(name string, _ map[string]bool) bool { return isSubjectsFile(name) },
+		value: func() interface{} { return new(api.SchemaSubjectsFile) }, check: checkSchemaSubjects},
+	{Kind: "Schema", Path: pkg.SchemasPath, value: func() interface{} { return new(api.SchemaAsRequest) }, check: checkSchema},
+	{Kind: "ACLList", Path: pkg.AclsPath, value: func() interface{} { return new([]api.ACL) }, check: checkACLs},
+	{Kind: "QuotaList", Path: pkg.QuotasPath, value: func() interface{} { return new([]api.CreateQuotaPayload) }, check: checkQuotas},
+	{Kind: "BrokerConfigs", Path: pkg.BrokerConfigsPath, value: func() interface{} { return new(api.BrokerConfigsPayload) }},
+	{Kind: "Group", Path: pkg.GroupsPath, value: func() interface{} { return new(api.Group) }, check: func(v interface{}) []string {
+		return requireName(v.(*api.Group).Name)
+	}},
+	{Kind: "ServiceAccount", Path: pkg.ServiceAccountsPath, value: func() interface{} { return new(api.ServiceAccount) }, check: func(v interface{}) []string {
+		return requireName(v.(*api.ServiceAccount).Name)
+	}},
+	{Kind: "Policy", Path: pkg.PoliciesPath, value: func() interface{} { return new(api.DataPolicyRequest) }, check: func(v interface{}) []string {
+		return requireName(v.(*api.DataPolicyRequest).Name)
+	}},
+	{Kind: "ProcessorMetadata", Path: pkg.SQLPath, match: func(name string, names map[string]bool) bool {
+		return names[strings.TrimSuffix(name, filepath.Ext(name))+".sql"]
+	}, value: func() interface{} { return new(api.ProcessorMetadataFilePayload) }},
+	{Kind: "Processor", Path: pkg.SQLPath, match: func(name string, _ map[string]bool) bool { return filepath.Ext(name) != ".sql" },
+		value: func() interface{} { return new(api.CreateProcessorFilePayload) }, check: checkProcessor},
+	{Kind: "Connector", Path: pkg.ConnectorsPath, match: func(name string, _ map[string]bool) bool { return filepath.Ext(name) != ".properties" },
+		value: func() interface{} { return new(api.CreateUpdateConnectorPayload) }, check: checkConnector},
+	{Kind: "ConsumerAlertSettings", Path: pkg.AlertSettingsPath, match: func(name string, _ map[string]bool) bool {
+		return strings.TrimSuffix(name, filepath.Ext(name)) == "alert-setting-consumer"
+	}, value: func() interface{} { return new(api.ConsumerAlertSettings) }},
+	{Kind: "ProducerAlertSettings", Path: pkg.AlertSettingsPath, match: func(name string, _ map[string]bool) bool {
+		return strings.TrimSuffix(name, filepath.Ext(name)) == "alert-setting-producer"
+	}, value: func() interface{} { return new(api.ProducerAlertSettings) }},
+	{Kind: "Datasets", Path: pkg.DatasetsPath, value: func() interface{} { return new(api.DatasetsPayload) }},
+	{Kind: "ConsumerOffsets", Path: pkg.ConsumerOffsetsPath, value: func() interface{} { return new(api.ConsumerGroupOffsetsPayload) }, check: func(v interface{}) []string {
+		if v.(*api.ConsumerGroupOffsetsPayload).Group == "" {
+			return []string{"group is required"}
+		}
+		return nil
+	}},
+}
+
+// fileKindOf returns the kind of a file of a resource directory, it returns false for the files that are not decoded.
+func fileKindOf(path, name string, names map[string]bool) (landscapeFileKind, bool) {
+	var fallback *landscapeFileKind
+	for i, kind := range landscapeFileKinds {
+		if kind.Path != path {
+			continue
+		}
+		if kind.match == nil {
+			if fallback == nil {
+				fallback = &landscapeFileKinds[i]
+			}
+			continue
+		}
+		if kind.match(name, names) {
+			return kind, true
+		}
+	}
+
+	if fallback != nil {
+		return *fallback, true
+	}
+
+	return landscapeFileKind{}, false
+}
+
+// CanValidate registers the `--overlay`, `--values` and `--set` flags, the landscape files are validated as `import` renders them.
+func CanValidate(cmd *cobra.Command) {
+	canOverlay(cmd)
+}
+
+// ValidateLandscape decodes every file of a landscape strictly and checks its values offline.
+func ValidateLandscape(cmd *cobra.Command, dir string) ([]FileProblem, error) {
+	opts, err := overlayOptionsFrom(cmd)
+	if err != nil {
+		return nil, err
+	}
+	opts.offline = true
+
+	var resources []landscapeResource
+	for _, kind := range utils.DocumentKinds {
+		resources = append(resources, landscapeResource{Name: kind.Kind, Path: kind.Path})
+	}
+
+	resources, err = discoverResources(dir, opts.overlay, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []FileProblem{}
+	report := func(file string, messages ...string) {
+		for _, message := range messages {
+			problems = append(problems, FileProblem{File: file, Problem: message})
+		}
+	}
+
+	for _, resource := range resources {
+		loadpath := filepath.Join(dir, resource.Path)
+		files, err := landscapeFiles(opts, loadpath)
+		if err != nil {
+			report(loadpath, err.Error())
+			continue
+		}
+
+		names := make(map[string]bool, len(files))
+		for _, file := range files {
+			names[file.Name()] = true
+		}
+
+		for _, file := range files {
+			path := filepath.Join(loadpath, file.Name())
+			report(path, validateFile(opts, resource.Path, path, names)...)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].File < problems[j].File })
+	return problems, nil
+}
+
+// validateFile returns the problems of a file of the "resource" directory.
+func validateFile(opts overlayOptions, resource, path string, names map[string]bool) []string {
+	switch ext := filepath.Ext(path); {
+	case resource == pkg.SQLPath && ext == ".sql":
+		sql, err := opts.renderText(path)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		if strings.TrimSpace(sql) == "" {
+			return []string{"the SQL of the processor is empty"}
+		}
+		return nil
+	case resource == pkg.ConnectorsPath && ext == ".properties":
+		text, err := opts.renderText(path)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		properties, err := utils.UnmarshalProperties([]byte(text))
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return checkConnector(&api.CreateUpdateConnectorPayload{Config: api.ConnectorConfigFromProperties(properties)})
+	case resource == pkg.SchemasPath && ext == ".avsc":
+		// the .avsc files are validated through the subjects file mapping them.
+		return nil
+	}
+
+	kind, ok := fileKindOf(resource, filepath.Base(path), names)
+	if !ok {
+		return nil
+	}
+
+	b, err := opts.render(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	v := kind.value()
+	if err = decodeStrict(path, b, v); err != nil {
+		return []string{fmt.Sprintf("invalid %s file. [%v]", kind.Kind, err)}
+	}
+
+	if kind.check == nil {
+		return nil
+	}
+
+	problems := kind.check(v)
+	if kind.Kind == "SchemaSubjects" {
+		problems = append(problems, checkSubjectFiles(opts, filepath.Dir(path), v.(*api.SchemaSubjectsFile))...)
+	}
+
+	return problems
+}
+
+// decodeStrict decodes a landscape file like unmarshalByExt, the fields unknown to "outPtr" are errors.
+func decodeStrict(file string, b []byte, outPtr interface{}) error {
+	switch filepath.Ext(file) {
+	case ".yml", ".yaml":
+		return yaml.UnmarshalStrict(b, outPtr)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(outPtr); err != nil {
+			return err
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return errors.New("unexpected data after the JSON value")
+		}
+		return nil
+	}
+}
+
+func requireName(name string) []string {
+	if strings.TrimSpace(name) == "" {
+		return []string{"name is required"}
+	}
+	return nil
+}
+
+func checkChannel(v interface{}) []string {
+	channel := v.(*api.ChannelPayload)
+	problems := requireName(channel.Name)
+	if channel.ConnectionName == "" {
+		problems = append(problems, "connectionName is required")
+	}
+	return problems
+}
+
+func checkTopic(v interface{}) []string {
+	topic := v.(*api.CreateTopicPayload)
+
+	problems := requireName(topic.TopicName)
+	if topic.Partitions < 1 {
+		problems = append(problems, fmt.Sprintf("partitions must be at least 1, got [%d]", topic.Partitions))
+	}
+	if topic.Replication < 1 {
+		problems = append(problems, fmt.Sprintf("replication must be at least 1, got [%d]", topic.Replication))
+	}
+
+	keys := make([]string, 0, len(topic.Configs))
+	for key := range topic.Configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !api.IsValidTopicConfigKey(key) {
+			problems = append(problems, fmt.Sprintf("unknown topic config [%s]", key))
+		}
+	}
+
+	return problems
+}
+
+func checkSchema(v interface{}) []string {
+	schema := v.(*api.SchemaAsRequest)
+
+	problems := requireName(schema.Name)
+	if !json.Valid([]byte(schema.AvroSchema)) {
+		problems = append(problems, "avroSchema is not a valid JSON Avro schema")
+	}
+	return problems
+}
+
+func checkSchemaSubjects(v interface{}) []string {
+	var problems []string
+	seen := make(map[string]bool)
+	for i, subject := range v.(*api.SchemaSubjectsFile).Subjects {
+		if subject.Subject == "" {
+			problems = append(problems, fmt.Sprintf("subjects[%d] has no subject", i))
+		} else if seen[subject.Subject] {
+			problems = append(problems, fmt.Sprintf("subject [%s] is mapped more than once", subject.Subject))
+		}
+		seen[subject.Subject] = true
+
+		if subject.File == "" {
+			problems = append(problems, fmt.Sprintf("subjects[%d] has no file", i))
+		}
+	}
+	return problems
+}
+
+// checkSubjectFiles checks the .avsc files mapped by a subjects file of the "loadpath" directory.
+func checkSubjectFiles(opts overlayOptions, loadpath string, mapping *api.SchemaSubjectsFile) []string {
+	var problems []string
+	for _, subject := range mapping.Subjects {
+		if subject.File == "" {
+			continue
+		}
+
+		avroSchema, err := opts.renderText(filepath.Join(loadpath, filepath.FromSlash(subject.File)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				err = fmt.Errorf("file [%s] not found", subject.File)
+			}
+			problems = append(problems, fmt.Sprintf("subject [%s]: %v", subject.Subject, err))
+			continue
+		}
+
+		if !json.Valid([]byte(avroSchema)) {
+			problems = append(problems, fmt.Sprintf("subject [%s]: [%s] is not a valid JSON Avro schema", subject.Subject, subject.File))
+		}
+	}
+	return problems
+}
+
+func checkACLs(v interface{}) []string {
+	var problems []string
+	for i, acl := range *v.(*[]api.ACL) {
+		// Validate normalises the ACL, the file keeps its values.
+		acl := acl
+		if err := acl.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("acl[%d]: %v", i, err))
+		}
+
+		if permission := string(acl.PermissionType); !strings.EqualFold(permission, string(api.ACLPermissionAllow)) && !strings.EqualFold(permission, string(api.ACLPermissionDeny)) {
+			problems = append(problems, fmt.Sprintf("acl[%d]: invalid permission type [%s], expected [%s] or [%s]", i, permission, api.ACLPermissionAllow, api.ACLPermissionDeny))
+		}
+
+		if acl.Principal == "" {
+			problems = append(problems, fmt.Sprintf("acl[%d]: principal is required", i))
+		}
+	}
+	return problems
+}
+
+var quotaTypes = []api.QuotaEntityType{
+	api.QuotaEntityClient,
+	api.QuotaEntityClients,
+	api.QuotaEntityClientsDefault,
+	api.QuotaEntityUser,
+	api.QuotaEntityUsers,
+	api.QuotaEntityUserClient,
+	api.QuotaEntityUsersDefault,
+}
+
+func checkQuotas(v interface{}) []string {
+	var problems []string
+	for i, quota := range *v.(*[]api.CreateQuotaPayload) {
+		valid := false
+		for _, quotaType := range quotaTypes {
+			valid = valid || string(quotaType) == quota.QuotaType
+		}
+		if !valid {
+			problems = append(problems, fmt.Sprintf("quota[%d]: invalid type [%s], expected one of %v", i, quota.QuotaType, quotaTypes))
+		}
+
+		config := map[string]string{
+			"producerByteRate":  quota.Config.ProducerByteRate,
+			"consumerByteRate":  quota.Config.ConsumerByteRate,
+			"requestPercentage": quota.Config.RequestPercentage,
+		}
+		keys := []string{"producerByteRate", "consumerByteRate", "requestPercentage"}
+
+		set := false
+		for _, key := range keys {
+			value := config[key]
+			if value == "" {
+				continue
+			}
+			set = true
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				problems = append(problems, fmt.Sprintf("quota[%d]: %s [%s] is not a number", i, key, value))
+			}
+		}
+		if !set {
+			problems = append(problems, fmt.Sprintf("quota[%d]: config has none of %s", i, strings.Join(keys, ", ")))
+		}
+	}
+	return problems
+}
+
+func checkProcessor(v interface{}) []string {
+	processor := v.(*api.CreateProcessorFilePayload)
+
+	problems := requireName(processor.Name)
+	if strings.TrimSpace(processor.SQL) == "" {
+		problems = append(problems, "sql is required")
+	}
+	if processor.Runners < 0 {
+		problems = append(problems, fmt.Sprintf("runnerCount must not be negative, got [%d]", processor.Runners))
+	}
+	return problems
+}
+
+func checkConnector(v interface{}) []string {
+	connector := v.(*api.CreateUpdateConnectorPayload)
+	if err := connector.ApplyAndValidateName(); err != nil {
+		return []string{err.Error()}
+	}
+
+	if connector.Name == "" {
+		return []string{"name is required"}
+	}
+
+	if class, _ := connector.Config[connectorClassKey].(string); class == "" {
+		return []string{connectorClassKey + " is required"}
+	}
+	return nil
+}
+
+// WriteProblems writes the human readable form of the problems of a landscape.
+func WriteProblems(w io.Writer, problems []FileProblem) {
+	files := make(map[string]bool)
+	for _, problem := range problems {
+		files[problem.File] = true
+		fmt.Fprintf(w, "%s: %s\n", problem.File, problem.Problem)
+	}
+
+	fmt.Fprintf(w, "Validation: %d problems in %d files.\n", len(problems), len(files))
+}
+
+// LandscapeSchemas returns the JSON Schemas of the landscape file kinds, keyed by the name of their file.
+func LandscapeSchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{})
+	for _, kind := range landscapeFileKinds {
+		_, hasDocuments := utils.PathOfKind(kind.Kind)
+
+		for _, tag := range []string{"yaml", "json"} {
+			spec := utils.JSONSchemaOf(kind.value(), tag)
+
+			schema := map[string]interface{}{
+				"$schema": utils.JSONSchemaDraft,
+				"title":   fmt.Sprintf("%s landscape file", kind.Kind),
+			}
+
+			if hasDocuments {
+				document := map[string]interface{}{
+					"type":     "object",
+					"required": []string{"apiVersion", "kind", "spec"},
+					"properties": map[string]interface{}{
+						"apiVersion": map[string]interface{}{"const": utils.DocumentAPIVersion},
+						"kind":       map[string]interface{}{"const": kind.Kind},
+						"metadata":   utils.JSONSchemaOf(utils.DocumentMetadata{}, "yaml"),
+						"spec":       spec,
+					},
+					"additionalProperties": false,
+				}
+				schema["anyOf"] = []interface{}{spec, document}
+			} else {
+				for k, v := range spec {
+					schema[k] = v
+				}
+			}
+
+			schemas[fmt.Sprintf("%s.%s.schema.json", kebabCase(kind.Kind), tag)] = schema
+		}
+	}
+
+	return schemas
+}
+
+// kebabCase returns the kebab case form of a kind, e.g. "alert-channel" for "AlertChannel".
+func kebabCase(kind string) string {
+	var b strings.Builder
+	for i, r := range kind {
+		if r >= 'A' && r <= 'Z' {
+			// an acronym, e.g. the "ACL" of "ACLList", is a single word.
+			if i > 0 && !(kind[i-1] >= 'A' && kind[i-1] <= 'Z') {
+				b.WriteByte('-')
+			} else if i > 0 && i+1 < len(kind) && kind[i+1] >= 'a' && kind[i+1] <= 'z' {
+				b.WriteByte('-')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package imports
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLandscape(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "validate-landscape", map[string]string{
+		pkg.TopicsPath + "/topic-orders.yaml":   "name: orders\npartitions: ${partitions}\nreplicaton: 3\nconfigs:\n  retention.ms: \"1000\"\n",
+		pkg.TopicsPath + "/topic-payments.yaml": "name: payments\npartitions: 1\nreplication: 1\nconfigs:\n  retension.ms: \"1000\"\n  confluent.placement.constraints: \"\"\n",
+		pkg.AclsPath + "/acls.yaml": `- resourceType: TOPIC
+  resourceName: orders
+  principal: User:bob
+  permissionType: ALLOW
+  host: '*'
+  operation: READ
+- resourceType: GROUP
+  resourceName: orders
+  principal: User:bob
+  permissionType: allow
+  host: '*'
+  operation: ALTER
+`,
+		pkg.QuotasPath + "/quotas.yaml":                  "- type: CLIENT\n  client: '*'\n  config:\n    producerByteRate: fast\n- type: TEAM\n  config:\n    consumerByteRate: \"1000\"\n",
+		pkg.SQLPath + "/audit.sql":                       "  \n",
+		pkg.SQLPath + "/audit.yaml":                      "runnerCount: 1\nrunners: 2\n",
+		pkg.ConnectorsPath + "/file-sink.properties":     "name=file-sink\ntopics=orders\n",
+		pkg.ConnectorsPath + "/file-source.json":         `{"name": "file-source", "config": {"connector.class": "FileStreamSource"}}`,
+		pkg.SchemasPath + "/subjects.yaml":               "subjects:\n- subject: orders-value\n  file: avro/Order.avsc\n- subject: refunds-value\n  file: avro/Refund.avsc\n",
+		pkg.SchemasPath + "/avro/Order.avsc":             `{"type": "record", "name": "Order", "fields": []}`,
+		pkg.SchemasPath + "/schema-orders-key.json":      `{"subject": "orders-key", "schema": "\"string\""}`,
+		pkg.ServiceAccountsPath + "/svc-accounts-a.yaml": "name: a\ngroups: [admins]\nowner: ${vault:secret/data/owners#a}\n",
+		"landscape.yaml": `apiVersion: landscape.lenses.io/v1
+kind: Group
+metadata:
+  name: admins
+spec:
+  name: admins
+  adminPermissions: [ViewKafkaSettings]
+  descripton: typo
+`,
+	})
+	defer remove()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dir", dir, "")
+	CanValidate(cmd)
+	assert.Nil(t, cmd.Flags().Set(setFlagKey, "partitions=3"))
+
+	problems, err := ValidateLandscape(cmd, dir)
+	assert.Nil(t, err)
+
+	byFile := make(map[string][]string)
+	for _, problem := range problems {
+		rel, err := filepath.Rel(dir, problem.File)
+		assert.Nil(t, err)
+		byFile[filepath.ToSlash(rel)] = append(byFile[filepath.ToSlash(rel)], problem.Problem)
+	}
+
+	assert.Equal(t, map[string][]string{
+		pkg.TopicsPath + "/topic-orders.yaml":   {"invalid Topic file. [yaml: unmarshal errors:\n  line 3: field replicaton not found in type api.CreateTopicPayload]"},
+		pkg.TopicsPath + "/topic-payments.yaml": {"unknown topic config [retension.ms]"},
+		pkg.AclsPath + "/acls.yaml":             {"acl[1]: invalid operation for resource type: [GROUP]. The valid operations for this type are: [[ALL READ DESCRIBE DELETE]]"},
+		pkg.QuotasPath + "/quotas.yaml": {
+			"quota[0]: producerByteRate [fast] is not a number",
+			"quota[1]: invalid type [TEAM], expected one of [CLIENT CLIENTS CLIENTS DEFAULT USER USERS USERCLIENT USERS DEFAULT]",
+		},
+		pkg.SQLPath + "/audit.sql":                   {"the SQL of the processor is empty"},
+		pkg.SQLPath + "/audit.yaml":                  {"invalid ProcessorMetadata file. [yaml: unmarshal errors:\n  line 2: field runners not found in type api.ProcessorMetadataFilePayload]"},
+		pkg.ConnectorsPath + "/file-sink.properties": {"connector.class is required"},
+		pkg.SchemasPath + "/subjects.yaml":           {"subject [refunds-value]: file [avro/Refund.avsc] not found"},
+		pkg.GroupsPath + "/admins.yaml":              {"invalid Group file. [yaml: unmarshal errors:\n  line 3: field descripton not found in type api.Group]"},
+	}, byFile)
+}
+
+func TestLandscapeSchemas(t *testing.T) {
+	schemas := LandscapeSchemas()
+	assert.Len(t, schemas, 2*len(landscapeFileKinds))
+
+	topic := schemas["topic.yaml.schema.json"]
+	assert.Equal(t, "Topic landscape file", topic["title"])
+
+	anyOf := topic["anyOf"].([]interface{})
+	spec := anyOf[0].(map[string]interface{})
+	assert.Equal(t, false, spec["additionalProperties"])
+	assert.Contains(t, spec["properties"], "replication")
+	assert.Contains(t, schemas["topic.json.schema.json"]["anyOf"].([]interface{})[0].(map[string]interface{})["properties"], "topicName")
+
+	// the kinds without documents are the spec.
+	assert.Equal(t, "object", schemas["schema-subjects.yaml.schema.json"]["type"])
+	assert.Equal(t, "array", schemas["acl-list.json.schema.json"]["anyOf"].([]interface{})[0].(map[string]interface{})["type"])
+}
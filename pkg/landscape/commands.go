@@ -0,0 +1,169 @@
+package landscape
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/bite"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	imports "github.com/lensesio/lenses-go/pkg/import"
+	"github.com/lensesio/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+//NewLandscapeGroupCommand creates `landscape` command
+func NewLandscapeGroupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "landscape",
+		Short: "Work with landscape directories",
+		Example: `
+landscape diff --dir my-landscape
+landscape diff --dir my-landscape --prune --output json
+landscape validate --dir my-landscape
+landscape schemas --dir landscape-schemas`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+	}
+
+	cmd.AddCommand(NewLandscapeDiffCommand())
+	cmd.AddCommand(NewLandscapeValidateCommand())
+	cmd.AddCommand(NewLandscapeSchemasCommand())
+
+	return cmd
+}
+
+//NewLandscapeDiffCommand creates `landscape diff` command
+func NewLandscapeDiffCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Print the drift between a landscape directory and the live environment",
+		Long: `Print the drift between a landscape directory and the live environment of the current context.
+Exits with 0 when the environment matches the landscape, 2 when it drifted and 1 on errors.`,
+		Example: `
+landscape diff --dir my-landscape
+landscape diff --dir my-landscape --context production --prune --prune-protect='_*' --output json
+landscape diff --dir my-landscape --context production --overlay overlays/prod --values values-prod.yaml`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			drifts, err := imports.DiffLandscape(config.Client, cmd, path)
+			if err != nil {
+				return err
+			}
+
+			if output := strings.ToUpper(bite.GetOutPutFlag(cmd)); output == "JSON" || output == "YAML" {
+				if err := bite.PrintObject(cmd, drifts); err != nil {
+					return err
+				}
+			} else {
+				imports.WriteDrifts(cmd.OutOrStdout(), drifts)
+			}
+
+			if len(drifts) > 0 {
+				return imports.ErrDriftDetected
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory of the landscape to compare")
+
+	imports.CanDiff(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+//NewLandscapeValidateCommand creates `landscape validate` command
+func NewLandscapeValidateCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the files of a landscape directory offline",
+		Long: `Decode every file of a landscape strictly, a field unknown to the kind of its file is a problem, e.g. a "replicaton:" typo,
+and check its values without connecting to Lenses: the operations of the ACLs against their resource types, the types and values of the quotas,
+the topic configs, the Avro schemas and the names the resources require. The secret placeholders are not resolved.
+The compatibility levels of the schemas are not checked, the schema files of a landscape do not hold them.
+Exits with 0 when the landscape is valid and 1 otherwise.`,
+		Example: `
+landscape validate --dir my-landscape
+landscape validate --dir my-landscape --overlay overlays/prod --values values-prod.yaml --output json`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems, err := imports.ValidateLandscape(cmd, path)
+			if err != nil {
+				return err
+			}
+
+			if output := strings.ToUpper(bite.GetOutPutFlag(cmd)); output == "JSON" || output == "YAML" {
+				if err := bite.PrintObject(cmd, problems); err != nil {
+					return err
+				}
+			} else {
+				imports.WriteProblems(cmd.OutOrStdout(), problems)
+			}
+
+			if len(problems) > 0 {
+				return imports.ErrLandscapeInvalid
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "dir", ".", "Base directory of the landscape to validate")
+
+	imports.CanValidate(cmd)
+	bite.CanPrintJSON(cmd)
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+//NewLandscapeSchemasCommand creates `landscape schemas` command
+func NewLandscapeSchemasCommand() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "schemas",
+		Short: "Write the JSON Schemas of the landscape files",
+		Long: `Write a JSON Schema per kind of landscape file and format, e.g. topic.yaml.schema.json and topic.json.schema.json,
+for editors to validate the landscape files as they are written. The fields unknown to a kind are not allowed.`,
+		Example: `
+landscape schemas --dir landscape-schemas`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schemas := imports.LandscapeSchemas()
+
+			names := make([]string, 0, len(schemas))
+			for name := range schemas {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				b, err := json.MarshalIndent(schemas[name], "", "  ")
+				if err != nil {
+					return err
+				}
+
+				if err = utils.WriteBytesFile(dir, "", name, append(b, '\n')); err != nil {
+					return err
+				}
+			}
+
+			return bite.PrintInfo(cmd, "Wrote [%d] JSON Schemas to [%s]", len(names), dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", ".", "Directory to write the JSON Schemas to")
+
+	bite.CanBeSilent(cmd)
+	return cmd
+}
@@ -323,3 +323,28 @@ func CreateQuotaForUsers(cmd *cobra.Command, client *api.Client, quota api.Creat
 
 	return err
 }
+
+// DeleteQuota deletes all the properties of a quota, it's the reverse of `CreateQuotaForClients` and `CreateQuotaForUsers`
+func DeleteQuota(client *api.Client, quota api.CreateQuotaPayload) error {
+	if strings.HasPrefix(quota.QuotaType, "CLIENT") {
+		if id := quota.ClientID; id != "" && id != "all" && id != "*" {
+			return client.DeleteQuotaForClient(id)
+		}
+
+		return client.DeleteQuotaForAllClients()
+	}
+
+	if user := quota.User; user != "" && user != "*" && strings.HasPrefix(quota.QuotaType, "USER") {
+		if clientID := quota.ClientID; clientID != "" {
+			if clientID == "all" || clientID == "*" {
+				return client.DeleteQuotaForUserAllClients(user)
+			}
+
+			return client.DeleteQuotaForUserClient(user, clientID)
+		}
+
+		return client.DeleteQuotaForUser(user)
+	}
+
+	return client.DeleteQuotaForAllUsers()
+}
@@ -0,0 +1,158 @@
+package secret
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	azure "github.com/Azure/go-autorest/autorest/azure"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const (
+	// VaultReference is the provider of the `${vault:path#key}` secret references.
+	VaultReference = "vault"
+	// AzureReference is the provider of the `${azure:vault-name/secret-name}` secret references.
+	AzureReference = "azure"
+	// EnvReference is the provider of the `${env:NAME}` secret references.
+	EnvReference = "env"
+)
+
+// IsReferenceProvider reports whether a provider of a `${provider:reference}` placeholder is resolved by the Resolver.
+func IsReferenceProvider(provider string) bool {
+	switch provider {
+	case VaultReference, AzureReference, EnvReference:
+		return true
+	default:
+		return false
+	}
+}
+
+// Resolver resolves secret references to their values with the environment variables of the `secrets` commands.
+type Resolver struct {
+	mu        sync.Mutex
+	vault     *vaultapi.Client
+	keyVaults map[string]*KeyVault
+}
+
+// NewResolver returns a new secret Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{keyVaults: make(map[string]*KeyVault)}
+}
+
+// Resolve returns the value of a secret reference of a provider, e.g. "vault" and "secret/data/db#password".
+func (r *Resolver) Resolve(provider, reference string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch provider {
+	case VaultReference:
+		return r.resolveVault(reference)
+	case AzureReference:
+		return r.resolveAzure(reference)
+	case EnvReference:
+		value, ok := os.LookupEnv(reference)
+		if !ok {
+			return "", fmt.Errorf("environment variable [%s] is not set", reference)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("unknown secret provider [%s]", provider)
+	}
+}
+
+// resolveVault reads the key of a secret, "secret/data/db#password", from the KV secrets engine, version 1 or 2.
+func (r *Resolver) resolveVault(reference string) (string, error) {
+	path, key, ok := splitReference(reference, "#")
+	if !ok {
+		return "", fmt.Errorf("invalid vault reference [%s], expected path#key", reference)
+	}
+
+	if r.vault == nil {
+		client, err := getVaultClient("", "")
+		if err != nil {
+			return "", fmt.Errorf("unable to create the vault client, set the %s and %s environment variables", vaultapi.EnvVaultAddress, vaultapi.EnvVaultToken)
+		}
+
+		if role := os.Getenv(EnvVaultRole); role != "" {
+			credentials, err := getVaultAppIDs(client, role)
+			if err != nil {
+				return "", err
+			}
+
+			if err = vaultAppRoleLogin(client, credentials); err != nil {
+				return "", err
+			}
+		}
+
+		r.vault = client
+	}
+
+	secret, err := r.vault.Logical().Read(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault secret [%s]. [%v]", path, err)
+	}
+
+	if secret == nil {
+		return "", fmt.Errorf("vault secret [%s] not found", path)
+	}
+
+	data := secret.Data
+	if nested, ok := secret.Data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "", fmt.Errorf("key [%s] not found in vault secret [%s]", key, path)
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+// resolveAzure reads a secret, "kv-name/secret-name", from an Azure Key Vault.
+func (r *Resolver) resolveAzure(reference string) (string, error) {
+	vaultName, name, ok := splitReference(reference, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid azure reference [%s], expected vault-name/secret-name", reference)
+	}
+
+	client, ok := r.keyVaults[vaultName]
+	if !ok {
+		dns := os.Getenv(EnvAzureKeyVaultDNS)
+		if dns == "" {
+			dns = azure.PublicCloud.KeyVaultDNSSuffix
+		}
+
+		config := AzureConfiguration{
+			TenantID:     os.Getenv(EnvAzureClientTenantID),
+			ClientID:     os.Getenv(EnvAzureClientID),
+			ClientSecret: os.Getenv(EnvAzureClientSecret),
+			KeyVaultName: vaultName,
+		}
+
+		var err error
+		if client, err = newKeyVaultClient(fmt.Sprintf("https://%s.%s", vaultName, dns), config); err != nil {
+			return "", err
+		}
+
+		r.keyVaults[vaultName] = client
+	}
+
+	value, err := client.getSecret(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret [%s] from azure key vault [%s]. [%v]", name, vaultName, err)
+	}
+
+	return value, nil
+}
+
+func splitReference(reference, sep string) (string, string, bool) {
+	i := strings.LastIndex(reference, sep)
+	if i <= 0 || i == len(reference)-1 {
+		return "", "", false
+	}
+
+	return reference[:i], reference[i+1:], true
+}
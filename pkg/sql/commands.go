@@ -112,11 +112,23 @@ func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool,
 		}
 	}()
 
+	var renderer *statsRenderer
+	if stats {
+		// render them on the standard error, the records are printed on the standard output.
+		renderer = newStatsRenderer(cmd.ErrOrStderr())
+		conn.OnStats(renderer.Update)
+		defer renderer.Finish()
+	}
+
 	// we exit on error, the only one place that we directly exit from here.
 	errorReporter := func(resp websocket.LiveResponse) error {
 		// parse it, otherwise it shows it very ugly.
 		var errStr string
 		json.Unmarshal(resp.Data.Value, &errStr)
+		if renderer != nil {
+			// deferred calls do not run on exit.
+			renderer.Finish()
+		}
 		_, err = fmt.Fprintf(cmd.OutOrStderr(), "[%s]: [%s]\n", resp.Type, errStr)
 		os.Exit(1)
 		return err
@@ -126,15 +138,11 @@ func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool,
 	conn.OnError(errorReporter)
 	conn.OnInvalidRequest(errorReporter)
 
-	if stats {
-		conn.OnStats(func(resp websocket.LiveResponse) error {
-			err := bite.PrintJSON(cmd, resp)
-			return err
-		})
-	}
-
 	// first subscribe to any incoming kafka messages (as result of the lsql publish).
 	conn.OnRecordMessage(func(resp websocket.LiveResponse) error {
+		if renderer != nil {
+			renderer.Record()
+		}
 
 		var data interface{}
 
@@ -189,6 +197,10 @@ func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool,
 	})
 
 	conn.OnEnd(func(resp websocket.LiveResponse) error {
+		if renderer != nil {
+			renderer.Finish()
+		}
+
 		if !InteractiveShell && sqlLiveStream {
 			os.Exit(0)
 		} else {
@@ -265,7 +277,7 @@ func NewLiveLSQLCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&sqlLiveStream, "live-stream", false, "Run in continuous query mode")
-	cmd.Flags().BoolVar(&sqlStats, "stats", false, "Print query stats")
+	cmd.Flags().BoolVar(&sqlStats, "stats", false, "Print the query progress and a summary of its stats on the standard error")
 	cmd.Flags().BoolVar(&sqlKeys, "keys", false, "Print message keys")
 	cmd.Flags().BoolVar(&sqlKeysOnly, "keys-only", false, "Print message keys only")
 	cmd.Flags().BoolVar(&sqlMeta, "meta", false, "Print message metadata")
@@ -0,0 +1,69 @@
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/lensesio/lenses-go/pkg/websocket"
+)
+
+// Execute runs a single, non-live, statement and passes each of its records to "onRecord", which can be nil.
+func Execute(sql string, onRecord websocket.LiveListener) error {
+	currentConfig := config.Manager.Config.GetCurrent()
+
+	conn, err := websocket.OpenLiveConnection(websocket.LiveConfiguration{
+		Host:  currentConfig.Host,
+		Debug: currentConfig.Debug,
+		Message: websocket.Message{
+			Token: config.Client.Config.Token,
+			SQL:   sql,
+			Stats: 2,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	done := make(chan error, 1)
+	finish := func(err error) {
+		select {
+		case done <- err:
+		default: // already finished.
+		}
+	}
+
+	failure := func(resp websocket.LiveResponse) error {
+		var errStr string
+		json.Unmarshal(resp.Data.Value, &errStr)
+		finish(fmt.Errorf("[%s]: [%s]", resp.Type, errStr))
+		return nil
+	}
+
+	conn.OnError(failure)
+	conn.OnInvalidRequest(failure)
+
+	if onRecord != nil {
+		conn.OnRecordMessage(onRecord)
+	}
+
+	conn.OnEnd(func(resp websocket.LiveResponse) error {
+		finish(nil)
+		return nil
+	})
+
+	select {
+	case err = <-done:
+		return err
+	case err = <-conn.Err():
+		// the server closes the connection after the "END",
+		// the listeners fire before the next read so the result is already there.
+		select {
+		case doneErr := <-done:
+			return doneErr
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,496 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// The supported report formats of the `sql lint` command.
+const (
+	lintFormatText  = "text"
+	lintFormatJUnit = "junit"
+	lintFormatSARIF = "sarif"
+)
+
+type (
+	// lintSource is a single SQL statement to validate
+	// and the position of its first character inside the file it was found.
+	lintSource struct {
+		File      string
+		Processor string
+		SQL       string
+		// Line and Column of the first SQL character in the file, both start from 1.
+		Line, Column int
+		// Indent is the number of characters that precede each SQL line, after the first, in the file.
+		Indent int
+		// Inline reports whether the SQL is written in a single, quoted, line in the file.
+		Inline bool
+	}
+
+	// lintResult is a single lint reported by the server, positioned in the file.
+	lintResult struct {
+		File      string `json:"file" header:"File"`
+		Processor string `json:"processor,omitempty" header:"Processor"`
+		Line      int    `json:"line" header:"Line"`
+		Column    int    `json:"column" header:"Column"`
+		Severity  string `json:"severity" header:"Severity"`
+		Message   string `json:"message" header:"Message"`
+	}
+
+	// lintValidator is implemented by `api.Client#ValidateSQL`.
+	lintValidator func(sql string, caret int) (api.SQLValidationResponse, error)
+)
+
+//NewSQLGroupCommand creates `sql` command
+func NewSQLGroupCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:              "sql",
+		Short:            "Lenses SQL tools",
+		Example:          `sql lint ./landscape`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+	}
+
+	root.AddCommand(NewSQLLintCommand())
+
+	return root
+}
+
+//NewSQLLintCommand creates `sql lint` command
+func NewSQLLintCommand() *cobra.Command {
+	var format, outputFile string
+
+	cmd := &cobra.Command{
+		Use:              "lint",
+		Short:            "Validate the SQL of landscape processors and .sql files, without deploying them",
+		Example:          `sql lint ./landscape ./queries/enrich.sql --format=junit --output-file=sql-lint.xml`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("at least one file or landscape directory is required")
+			}
+
+			if err := checkLintFormat(format); err != nil {
+				return err
+			}
+
+			sources, err := findLintSources(args)
+			if err != nil {
+				return err
+			}
+
+			if len(sources) == 0 {
+				return fmt.Errorf("no processors or .sql files found in [%s]", strings.Join(args, ", "))
+			}
+
+			results, err := lintSources(config.Client.ValidateSQL, sources)
+			if err != nil {
+				return err
+			}
+
+			var report bytes.Buffer
+			if err = writeLintReport(&report, format, sources, results); err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				if _, err = report.WriteTo(cmd.OutOrStdout()); err != nil {
+					return err
+				}
+			} else if report.Len() > 0 {
+				if err = ioutil.WriteFile(outputFile, report.Bytes(), 0666); err != nil {
+					return err
+				}
+			}
+
+			if len(results) > 0 {
+				return fmt.Errorf("found [%d] lints in [%d] SQL statements", len(results), len(sources))
+			}
+
+			if outputFile != "" {
+				return bite.PrintInfo(cmd, "No lints found in [%d] SQL statements", len(sources))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", lintFormatText, "Report format: text, junit or sarif")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the report to a file instead of the standard output")
+
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+// findLintSources walks the given files and directories, a directory may be a landscape
+// whose processors are read from the `apps/sql` files, standalone .sql files are read from anywhere.
+func findLintSources(paths []string) ([]lintSource, error) {
+	var sources []lintSource
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			found, err := readLintSources(root, true)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, found...)
+			continue
+		}
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			found, err := readLintSources(path, isProcessorFile(path))
+			if err != nil {
+				return err
+			}
+			sources = append(sources, found...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
+}
+
+func isProcessorFile(path string) bool {
+	return strings.Contains(filepath.ToSlash(filepath.Dir(path)), pkg.SQLPath)
+}
+
+func readLintSources(path string, processor bool) ([]lintSource, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sql":
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []lintSource{{File: path, SQL: string(b), Line: 1, Column: 1}}, nil
+	case ".yaml", ".yml", ".json":
+		if !processor {
+			return nil, nil
+		}
+
+		var payload api.CreateProcessorFilePayload
+		if err := bite.TryReadFile(path, &payload); err != nil {
+			return nil, fmt.Errorf("unable to read processor file [%s]: [%v]", path, err)
+		}
+
+		if strings.TrimSpace(payload.SQL) == "" {
+			return nil, nil
+		}
+
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		source := lintSource{File: path, Processor: payload.Name, SQL: payload.SQL, Line: 1, Column: 1}
+		source.Line, source.Column, source.Indent, source.Inline = locateProcessorSQL(string(b))
+		return []lintSource{source}, nil
+	default:
+		return nil, nil
+	}
+}
+
+var sqlYAMLKey = regexp.MustCompile(`^(\s*)sql:\s*(.*)$`)
+
+// locateProcessorSQL returns the position and the indentation of the "sql" field's value of a processor YAML file.
+// It's a best effort, for JSON files or unexpected layouts the lints are positioned at the start of the file.
+func locateProcessorSQL(contents string) (line, column, indent int, inline bool) {
+	lines := strings.Split(contents, "\n")
+
+	for i, l := range lines {
+		matches := sqlYAMLKey.FindStringSubmatch(l)
+		if matches == nil {
+			continue
+		}
+
+		value := strings.TrimSpace(matches[2])
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			// block scalar, the SQL starts on the next line.
+			if i+1 < len(lines) {
+				next := lines[i+1]
+				indent = len(next) - len(strings.TrimLeft(next, " "))
+				return i + 2, indent + 1, indent, false
+			}
+			return i + 1, 1, 0, false
+		}
+
+		column = strings.Index(l, matches[2]) + 1
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+			column++
+		}
+
+		return i + 1, column, 0, true
+	}
+
+	return 1, 1, 0, false
+}
+
+// lintSources validates each source and returns the error and warning lints.
+func lintSources(validate lintValidator, sources []lintSource) ([]lintResult, error) {
+	var results []lintResult
+
+	for _, source := range sources {
+		validation, err := validate(source.SQL, 0)
+		if err != nil {
+			return nil, fmt.Errorf("unable to validate [%s]: [%v]", source.File, err)
+		}
+
+		for _, lint := range validation.Lints {
+			severity := strings.ToLower(lint.Type)
+			if severity != "error" && severity != "warning" {
+				continue
+			}
+
+			line, column := source.position(lint.Start)
+			results = append(results, lintResult{
+				File:      source.File,
+				Processor: source.Processor,
+				Line:      line,
+				Column:    column,
+				Severity:  severity,
+				Message:   lint.Text,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].File != results[j].File {
+			return results[i].File < results[j].File
+		}
+		return results[i].Line < results[j].Line
+	})
+
+	return results, nil
+}
+
+// position converts a character offset of the SQL to the line and column in the source file.
+func (s lintSource) position(offset int) (line, column int) {
+	line, column = s.Line, s.Column
+
+	for i, r := range []rune(s.SQL) {
+		if i >= offset {
+			break
+		}
+
+		if r == '\n' && s.Inline {
+			// escaped as "\n".
+			column += 2
+			continue
+		}
+
+		if r == '\n' {
+			line++
+			column = s.Indent + 1
+			continue
+		}
+
+		column++
+	}
+
+	return
+}
+
+func checkLintFormat(format string) error {
+	switch strings.ToLower(format) {
+	case lintFormatText, lintFormatJUnit, lintFormatSARIF:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format [%s], use one of: [%s, %s, %s]", format, lintFormatText, lintFormatJUnit, lintFormatSARIF)
+	}
+}
+
+func writeLintReport(w io.Writer, format string, sources []lintSource, results []lintResult) error {
+	switch strings.ToLower(format) {
+	case lintFormatText:
+		for _, r := range results {
+			if _, err := fmt.Fprintf(w, "%s:%d:%d: %s: %s\n", r.File, r.Line, r.Column, r.Severity, r.Message); err != nil {
+				return err
+			}
+		}
+		return nil
+	case lintFormatJUnit:
+		return writeJUnitReport(w, sources, results)
+	case lintFormatSARIF:
+		return writeSARIFReport(w, results)
+	default:
+		return checkLintFormat(format)
+	}
+}
+
+type (
+	junitTestSuites struct {
+		XMLName xml.Name         `xml:"testsuites"`
+		Suites  []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name     string          `xml:"name,attr"`
+		Tests    int             `xml:"tests,attr"`
+		Failures int             `xml:"failures,attr"`
+		Cases    []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string         `xml:"name,attr"`
+		ClassName string         `xml:"classname,attr"`
+		Failures  []junitFailure `xml:"failure,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// writeJUnitReport writes a test case per SQL statement, each lint is a failure of its statement.
+func writeJUnitReport(w io.Writer, sources []lintSource, results []lintResult) error {
+	suite := junitTestSuite{Name: "lenses-sql-lint", Tests: len(sources)}
+
+	for _, source := range sources {
+		name := source.File
+		if source.Processor != "" {
+			name = source.Processor
+		}
+
+		testCase := junitTestCase{Name: name, ClassName: source.File}
+		for _, r := range results {
+			if r.File == source.File && r.Processor == source.Processor {
+				testCase.Failures = append(testCase.Failures, junitFailure{
+					Message: r.Message,
+					Type:    r.Severity,
+					Text:    fmt.Sprintf("%s:%d:%d: %s", r.File, r.Line, r.Column, r.Message),
+				})
+			}
+		}
+
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	b, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, b)
+	return err
+}
+
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string `json:"name"`
+		Version        string `json:"version,omitempty"`
+		InformationURI string `json:"informationUri"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+)
+
+func writeSARIFReport(w io.Writer, results []lintResult) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "lenses-cli sql lint",
+			Version:        api.BuildVersion,
+			InformationURI: "https://docs.lenses.io",
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, r := range results {
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  "lenses-sql/" + r.Severity,
+			Level:   r.Severity,
+			Message: sarifMessage{Text: r.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(r.File)},
+				Region:           sarifRegion{StartLine: r.Line, StartColumn: r.Column},
+			}}},
+		})
+	}
+
+	b, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
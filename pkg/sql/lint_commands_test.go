@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const lintProcessorYAML = `name: enrich
+sql: |-
+  SET defaults.topic.autocreate=true;
+  INSERT INTO target SELECT STREAM * FROM sourc
+runnerCount: 1
+`
+
+func TestFindLintSourcesAndPositions(t *testing.T) {
+	// yaml files outside of the processors directory are not processors.
+	dir, remove := test.TempLandscape(t, "lenses-lint", map[string]string{
+		"apps/sql/processor-enrich.yaml": lintProcessorYAML,
+		"adhoc.sql":                      "SELECT *\nFROM x",
+		"topic.yaml":                     "sql: not a processor",
+	})
+	defer remove()
+
+	sources, err := findLintSources([]string{dir})
+	assert.Nil(t, err)
+	assert.Len(t, sources, 2)
+
+	validate := func(sql string, caret int) (api.SQLValidationResponse, error) {
+		start := strings.Index(sql, "sourc")
+		if start == -1 {
+			return api.SQLValidationResponse{Lints: []api.ValidationLints{{Type: "hint", Text: "ignored"}}}, nil
+		}
+		return api.SQLValidationResponse{Lints: []api.ValidationLints{{Start: start, End: start + 5, Type: "ERROR", Text: "Unknown topic sourc"}}}, nil
+	}
+
+	results, err := lintSources(validate, sources)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, lintResult{
+		File:      filepath.Join(dir, "apps", "sql", "processor-enrich.yaml"),
+		Processor: "enrich",
+		Line:      4,
+		Column:    43,
+		Severity:  "error",
+		Message:   "Unknown topic sourc",
+	}, results[0])
+
+	var text bytes.Buffer
+	assert.Nil(t, writeLintReport(&text, lintFormatText, sources, results))
+	assert.Equal(t, filepath.Join(dir, "apps", "sql", "processor-enrich.yaml")+":4:43: error: Unknown topic sourc\n", text.String())
+
+	var junit bytes.Buffer
+	assert.Nil(t, writeLintReport(&junit, lintFormatJUnit, sources, results))
+	assert.Contains(t, junit.String(), `<testsuite name="lenses-sql-lint" tests="2" failures="1">`)
+	assert.Contains(t, junit.String(), `<failure message="Unknown topic sourc" type="error">`)
+
+	var sarif bytes.Buffer
+	assert.Nil(t, writeLintReport(&sarif, lintFormatSARIF, sources, results))
+	var log sarifLog
+	assert.Nil(t, json.Unmarshal(sarif.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, 4, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestLocateInlineProcessorSQL(t *testing.T) {
+	line, column, _, inline := locateProcessorSQL("name: x\nsql: \"SELECT 1\"\n")
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 7, column)
+	assert.True(t, inline)
+
+	source := lintSource{SQL: "SELECT\n1", Line: line, Column: column, Inline: inline}
+	line, column = source.position(7)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 15, column)
+}
+
+func TestSQLLintUnsupportedFormatKeepsOutputFile(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "lenses-lint", nil)
+	defer remove()
+
+	outputFile := filepath.Join(dir, "sql-lint.xml")
+	assert.Nil(t, ioutil.WriteFile(outputFile, []byte("previous report"), 0666))
+
+	// the format is checked before the file is written, or any SQL is validated.
+	_, err := test.ExecuteCommand(NewSQLLintCommand(), dir, "--format", "xml", "--output-file", outputFile)
+	assert.EqualError(t, err, "unsupported format [xml], use one of: [text, junit, sarif]")
+
+	b, err := ioutil.ReadFile(outputFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "previous report", string(b))
+}
@@ -0,0 +1,174 @@
+package sql
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lensesio/lenses-go/pkg/websocket"
+)
+
+// statsRenderer renders the "STATS" responses of a query as a status line and a final summary,
+// it writes to the standard error so the records printed to the standard output can still be piped.
+type statsRenderer struct {
+	w io.Writer
+	// live reports whether the status line should be redrawn on each update,
+	// it's false when the output is not a terminal, only the summary is written then.
+	live bool
+
+	started  time.Time
+	returned int64
+	stats    websocket.Stats
+
+	mu       sync.Mutex
+	finished bool
+	// drawn reports whether the status line is on the screen and should be cleared before a record is printed.
+	drawn bool
+}
+
+func newStatsRenderer(w io.Writer) *statsRenderer {
+	return &statsRenderer{
+		w:       w,
+		live:    isTerminal(w),
+		started: time.Now(),
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Record counts a returned record and clears the status line, if any, so the record can be printed in its place.
+// The status line is redrawn on the next update.
+func (r *statsRenderer) Record() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.returned++
+	r.clear()
+}
+
+func (r *statsRenderer) clear() {
+	if r.drawn {
+		// carriage return and clear the line.
+		fmt.Fprint(r.w, "\r\033[K")
+		r.drawn = false
+	}
+}
+
+// Update keeps the latest stats and redraws the status line.
+func (r *statsRenderer) Update(resp websocket.LiveResponse) error {
+	stats, err := resp.Stats()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.finished {
+		return nil
+	}
+
+	r.stats = stats
+	if r.live {
+		r.clear()
+		fmt.Fprint(r.w, r.statusLine())
+		r.drawn = true
+	}
+
+	return nil
+}
+
+// Finish writes the summary, it's safe to be called more than once.
+func (r *statsRenderer) Finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.finished {
+		return
+	}
+	r.finished = true
+	r.clear()
+
+	fmt.Fprintf(r.w, "Records scanned: %d, returned: %d, skipped: %d\n", r.stats.TotalRecords, r.returned, r.stats.RecordsSkipped)
+	fmt.Fprintf(r.w, "Bytes read: %s, elapsed: %s\n", formatBytes(r.stats.TotalSizeRead), r.elapsed())
+
+	for _, p := range r.partitions() {
+		fmt.Fprintf(r.w, "Partition %d: offset %d of [%d, %d] (%s)\n", p.Partition, p.Offset, p.Begin, p.End, partitionProgress(p))
+	}
+}
+
+func (r *statsRenderer) statusLine() string {
+	line := fmt.Sprintf("scanned %d | returned %d | %s | %s", r.stats.TotalRecords, r.returned, formatBytes(r.stats.TotalSizeRead), r.elapsed())
+
+	partitions := r.partitions()
+	if len(partitions) == 0 {
+		return line
+	}
+
+	progress := make([]string, len(partitions))
+	for i, p := range partitions {
+		progress[i] = fmt.Sprintf("p%d %s", p.Partition, partitionProgress(p))
+	}
+
+	return line + " | " + strings.Join(progress, " ")
+}
+
+func (r *statsRenderer) elapsed() time.Duration {
+	return time.Since(r.started).Round(100 * time.Millisecond)
+}
+
+func (r *statsRenderer) partitions() []websocket.PartitionStats {
+	partitions := append([]websocket.PartitionStats(nil), r.stats.Offsets...)
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Partition < partitions[j].Partition
+	})
+
+	return partitions
+}
+
+func partitionProgress(p websocket.PartitionStats) string {
+	total := p.End - p.Begin
+	if total <= 0 {
+		return "100%"
+	}
+
+	read := p.Offset - p.Begin
+	if read < 0 {
+		read = 0
+	} else if read > total {
+		read = total
+	}
+
+	return fmt.Sprintf("%d%%", read*100/total)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRenderer(t *testing.T) {
+	var resp websocket.LiveResponse
+	err := json.Unmarshal([]byte(`{"type":"STATS","data":{"totalRecords":200,"recordsSkipped":2,"totalSizeRead":2048,
+		"offsets":[{"partition":1,"begin":0,"end":100,"offset":100},{"partition":0,"begin":10,"end":110,"offset":60}]}}`), &resp)
+	assert.Nil(t, err)
+
+	var out bytes.Buffer
+	renderer := newStatsRenderer(&out)
+	assert.False(t, renderer.live)
+
+	renderer.Record()
+	renderer.Record()
+	assert.Nil(t, renderer.Update(resp))
+	// not a terminal, only the summary is written.
+	assert.Empty(t, out.String())
+
+	assert.Contains(t, renderer.statusLine(), "scanned 200 | returned 2 | 2.0 KiB")
+	assert.True(t, strings.HasSuffix(renderer.statusLine(), "| p0 50% p1 100%"))
+
+	renderer.Finish()
+	renderer.Finish()
+
+	summary := out.String()
+	assert.Contains(t, summary, "Records scanned: 200, returned: 2, skipped: 2\n")
+	assert.Contains(t, summary, "Bytes read: 2.0 KiB, elapsed: ")
+	assert.Contains(t, summary, "Partition 0: offset 60 of [10, 110] (50%)\nPartition 1: offset 100 of [0, 100] (100%)\n")
+	assert.Equal(t, 1, strings.Count(summary, "Records scanned"))
+}
+
+func TestStatsRendererLiveClearsBeforeRecords(t *testing.T) {
+	var resp websocket.LiveResponse
+	err := json.Unmarshal([]byte(`{"type":"STATS","data":{"totalRecords":1}}`), &resp)
+	assert.Nil(t, err)
+
+	var out bytes.Buffer
+	renderer := newStatsRenderer(&out)
+	renderer.live = true
+
+	assert.Nil(t, renderer.Update(resp))
+	assert.True(t, strings.HasPrefix(out.String(), "scanned 1 | returned 0"))
+
+	// the status line is cleared once before the record is printed and redrawn on the next update.
+	out.Reset()
+	renderer.Record()
+	renderer.Record()
+	assert.Equal(t, "\r\033[K", out.String())
+
+	out.Reset()
+	assert.Nil(t, renderer.Update(resp))
+	assert.True(t, strings.HasPrefix(out.String(), "scanned 1 | returned 2"))
+
+	out.Reset()
+	renderer.Finish()
+	assert.True(t, strings.HasPrefix(out.String(), "\r\033[KRecords scanned: 1, returned: 2"))
+}
+
+func TestLiveResponseRecordData(t *testing.T) {
+	var resp websocket.LiveResponse
+	err := json.Unmarshal([]byte(`{"type":"RECORD","data":{"key":"k","value":{"a":1},"metadata":{"partition":2,"offset":7}}}`), &resp)
+	assert.Nil(t, err)
+	assert.Equal(t, websocket.RecordMessageResponse, resp.Type)
+	assert.Equal(t, `"k"`, string(resp.Data.Key))
+	assert.Equal(t, 2, resp.Data.Metadata.Partition)
+	assert.Equal(t, 7, resp.Data.Metadata.Offset)
+
+	_, err = resp.Stats()
+	assert.NotNil(t, err)
+}
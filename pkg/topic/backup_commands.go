@@ -0,0 +1,420 @@
+package topic
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	lsql "github.com/lensesio/lenses-go/pkg/sql"
+	"github.com/lensesio/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+)
+
+const backupManifestFile = "manifest.json"
+
+type (
+	// backupRecord is a single line of a backup chunk file.
+	backupRecord struct {
+		Key       json.RawMessage `json:"key,omitempty"`
+		Value     json.RawMessage `json:"value"`
+		Partition int             `json:"partition"`
+		Offset    int             `json:"offset"`
+		Timestamp int             `json:"timestamp"`
+	}
+
+	// backupChunk describes a gzip compressed, newline delimited JSON, file of a backup.
+	backupChunk struct {
+		File    string `json:"file"`
+		Records int    `json:"records"`
+		SHA256  string `json:"sha256"`
+	}
+
+	// backupManifest is written next to the chunks and it's read by the `topic restore` command.
+	backupManifest struct {
+		Topic      string        `json:"topic"`
+		Partitions int           `json:"partitions"`
+		Records    int           `json:"records"`
+		CreatedAt  int64         `json:"createdAt"`
+		Chunks     []backupChunk `json:"chunks"`
+	}
+)
+
+//NewTopicBackupCommand creates `topic backup` command
+func NewTopicBackupCommand() *cobra.Command {
+	var (
+		dir, maxSize, maxTime string
+		chunkSize             int
+	)
+
+	cmd := &cobra.Command{
+		Use:              "backup",
+		Short:            "Backup all the records of a topic to compressed NDJSON chunk files",
+		Example:          `topic backup my-topic --to=./backups/my-topic --chunk-size=10000 --max-size=1GB --max-time=1h`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("one argument is required for the topic name")
+			}
+
+			topicName := args[0]
+
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"to": dir}); err != nil {
+				return err
+			}
+
+			if chunkSize <= 0 {
+				return fmt.Errorf("chunk size must be greater than zero")
+			}
+
+			topic, err := config.Client.GetTopic(topicName)
+			if err != nil {
+				golog.Errorf("Failed to retrieve topic [%s]. [%s]", topicName, err.Error())
+				return err
+			}
+
+			writer, err := newBackupWriter(dir, chunkSize)
+			if err != nil {
+				return err
+			}
+
+			query := backupQuery(topicName, maxSize, maxTime)
+			if err = lsql.Execute(query, func(resp websocket.LiveResponse) error {
+				return writer.Write(backupRecord{
+					Key:       resp.Data.Key,
+					Value:     resp.Data.Value,
+					Partition: resp.Data.Metadata.Partition,
+					Offset:    resp.Data.Metadata.Offset,
+					Timestamp: resp.Data.Metadata.Timestamp,
+				})
+			}); err != nil {
+				writer.Close()
+				return fmt.Errorf("failed to backup topic [%s]. [%v]", topicName, err)
+			}
+
+			if err = writer.Close(); err != nil {
+				return err
+			}
+
+			manifest := backupManifest{
+				Topic:      topicName,
+				Partitions: topic.Partitions,
+				Records:    writer.records,
+				CreatedAt:  time.Now().UnixNano() / int64(time.Millisecond),
+				Chunks:     writer.chunks,
+			}
+
+			if err = writeBackupManifest(dir, manifest); err != nil {
+				return err
+			}
+
+			if messages := topicMessages(topic); int64(manifest.Records) < messages {
+				golog.Warnf("Backed up [%d] of the [%d] records of topic [%s], the query may have stopped early, check the --max-size and --max-time limits",
+					manifest.Records, messages, topicName)
+			}
+
+			return bite.PrintInfo(cmd, "Backed up [%d] records of topic [%s] to [%s] in [%d] chunks", manifest.Records, topicName, dir, len(manifest.Chunks))
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "to", "", "Directory to write the chunks and the manifest to")
+	cmd.Flags().IntVar(&chunkSize, "chunk-size", 10000, "Maximum number of records per chunk file")
+	cmd.Flags().StringVar(&maxSize, "max-size", "", "Maximum data size the query can read, e.g. 1GB, defaults to the server's setting")
+	cmd.Flags().StringVar(&maxTime, "max-time", "", "Maximum time the query can run, e.g. 1h, defaults to the server's setting")
+
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+//NewTopicRestoreCommand creates `topic restore` command
+func NewTopicRestoreCommand() *cobra.Command {
+	var (
+		dir, topicName string
+		batchSize      int
+		rate           float64
+	)
+
+	cmd := &cobra.Command{
+		Use:              "restore",
+		Short:            "Restore the records of a topic backup into a topic",
+		Example:          `topic restore --from=./backups/my-topic [--topic=my-topic-copy] --batch-size=100 --rate=500`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"from": dir}); err != nil {
+				return err
+			}
+
+			if batchSize <= 0 {
+				return fmt.Errorf("batch size must be greater than zero")
+			}
+
+			manifest, err := readBackupManifest(dir)
+			if err != nil {
+				return err
+			}
+
+			if topicName == "" {
+				topicName = manifest.Topic
+			}
+
+			target, err := config.Client.GetTopic(topicName)
+			if err != nil {
+				golog.Errorf("Failed to retrieve topic [%s], the target topic should exist. [%s]", topicName, err.Error())
+				return err
+			}
+
+			// the records are written to their backed up partitions only when the partitions of the topics match.
+			keepPartitions := target.Partitions == manifest.Partitions
+			if !keepPartitions {
+				golog.Warnf("Topic [%s] has [%d] partitions but the backup of [%s] had [%d], the records will be partitioned by their key",
+					topicName, target.Partitions, manifest.Topic, manifest.Partitions)
+			}
+
+			// all the chunks are read and their batches are built before producing, a broken backup produces nothing.
+			var (
+				chunkBatches [][]produceBatch
+				restored     int
+			)
+
+			for _, chunk := range manifest.Chunks {
+				records, err := readBackupChunk(dir, chunk, keepPartitions)
+				if err != nil {
+					return err
+				}
+
+				batches, err := newProduceBatches(topicName, records, batchSize)
+				if err != nil {
+					return fmt.Errorf("chunk [%s]: [%v]", chunk.File, err)
+				}
+
+				chunkBatches = append(chunkBatches, batches)
+				restored += len(records)
+			}
+
+			var failures []produceFailure
+			for i, batches := range chunkBatches {
+				chunkFailures := produceBatches(batches, rate, lsql.Execute)
+				for j := range chunkFailures {
+					chunkFailures[j].Reason = fmt.Sprintf("%s: %s", manifest.Chunks[i].File, chunkFailures[j].Reason)
+				}
+
+				failures = append(failures, chunkFailures...)
+			}
+
+			failed := 0
+			for _, f := range failures {
+				failed += f.Records
+			}
+
+			if failed > 0 {
+				if err := bite.PrintObject(cmd, failures); err != nil {
+					return err
+				}
+
+				return fmt.Errorf("failed to restore [%d] of [%d] records into topic [%s]", failed, restored, topicName)
+			}
+
+			return bite.PrintInfo(cmd, "Restored [%d] records of [%s] backup into topic [%s]", restored, manifest.Topic, topicName)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "from", "", "Backup directory, as created by the `topic backup` command")
+	cmd.Flags().StringVar(&topicName, "topic", "", "Topic to restore into, defaults to the backed up topic")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "Maximum number of records sent with a single INSERT statement")
+	cmd.Flags().Float64Var(&rate, "rate", 0, "Maximum number of records per second, zero means no limit")
+
+	bite.CanBeSilent(cmd)
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func backupQuery(topicName, maxSize, maxTime string) string {
+	var settings []string
+
+	if maxSize != "" {
+		settings = append(settings, fmt.Sprintf("SET max.size = %s;", quoteSQLString(maxSize)))
+	}
+
+	if maxTime != "" {
+		settings = append(settings, fmt.Sprintf("SET max.query.time = %s;", quoteSQLString(maxTime)))
+	}
+
+	return strings.TrimSpace(strings.Join(settings, " ") + " SELECT * FROM " + quoteSQLIdentifier(topicName))
+}
+
+// topicMessages returns the number of records of a topic, as reported by its partitions.
+func topicMessages(topic api.Topic) (messages int64) {
+	for _, p := range topic.MessagesPerPartition {
+		messages += p.Messages
+	}
+
+	return
+}
+
+// backupWriter writes records to gzip compressed chunk files of up to "chunkSize" records.
+type backupWriter struct {
+	dir       string
+	chunkSize int
+
+	file    *os.File
+	gz      *gzip.Writer
+	hash    hash.Hash
+	encoder *json.Encoder
+
+	chunks  []backupChunk
+	records int
+}
+
+func newBackupWriter(dir string, chunkSize int) (*backupWriter, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	return &backupWriter{dir: dir, chunkSize: chunkSize}, nil
+}
+
+func (w *backupWriter) Write(record backupRecord) error {
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.encoder.Encode(record); err != nil {
+		return err
+	}
+
+	w.records++
+	w.chunks[len(w.chunks)-1].Records++
+
+	if w.chunks[len(w.chunks)-1].Records >= w.chunkSize {
+		return w.closeChunk()
+	}
+
+	return nil
+}
+
+func (w *backupWriter) open() error {
+	name := fmt.Sprintf("chunk-%05d.ndjson.gz", len(w.chunks))
+
+	f, err := os.Create(filepath.Join(w.dir, name))
+	if err != nil {
+		return err
+	}
+
+	w.file = f
+	w.hash = sha256.New()
+	w.gz = gzip.NewWriter(io.MultiWriter(f, w.hash))
+	w.encoder = json.NewEncoder(w.gz)
+	w.chunks = append(w.chunks, backupChunk{File: name})
+	return nil
+}
+
+func (w *backupWriter) closeChunk() error {
+	if w.file == nil {
+		return nil
+	}
+
+	if err := w.gz.Close(); err != nil {
+		return err
+	}
+
+	w.chunks[len(w.chunks)-1].SHA256 = hex.EncodeToString(w.hash.Sum(nil))
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Close closes the last chunk, if any.
+func (w *backupWriter) Close() error {
+	return w.closeChunk()
+}
+
+func writeBackupManifest(dir string, manifest backupManifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, backupManifestFile), b, 0666)
+}
+
+func readBackupManifest(dir string) (manifest backupManifest, err error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, backupManifestFile))
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		err = fmt.Errorf("unable to read the backup manifest: [%v]", err)
+	}
+
+	return
+}
+
+// readBackupChunk verifies the checksum of a chunk and returns its records ready to be produced,
+// to their backed up partition if "keepPartitions" is true.
+func readBackupChunk(dir string, chunk backupChunk, keepPartitions bool) ([]produceRecord, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return nil, err
+	}
+
+	if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != chunk.SHA256 {
+		return nil, fmt.Errorf("chunk [%s] checksum mismatch, the backup is corrupted", chunk.File)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var records []produceRecord
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var record backupRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("chunk [%s], line [%d]: [%v]", chunk.File, line, err)
+		}
+
+		produce := produceRecord{Key: record.Key, Value: record.Value, line: line, whole: true}
+		if keepPartitions {
+			partition := record.Partition
+			produce.partition = &partition
+		}
+
+		records = append(records, produce)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("chunk [%s]: [%v]", chunk.File, err)
+	}
+
+	if len(records) != chunk.Records {
+		return nil, fmt.Errorf("chunk [%s] has [%d] records but the manifest expects [%d]", chunk.File, len(records), chunk.Records)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,100 @@
+package topic
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupWriterAndReadChunks(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "lenses-backup", nil)
+	defer remove()
+
+	writer, err := newBackupWriter(dir, 2)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, writer.Write(backupRecord{Key: []byte(`"k"`), Value: []byte(`{"n":1}`), Partition: 1, Offset: i}))
+	}
+	assert.Nil(t, writer.Close())
+
+	assert.Equal(t, 3, writer.records)
+	assert.Len(t, writer.chunks, 2)
+	assert.Equal(t, 2, writer.chunks[0].Records)
+	assert.Equal(t, 1, writer.chunks[1].Records)
+
+	manifest := backupManifest{Topic: "payments", Partitions: 3, Records: writer.records, Chunks: writer.chunks}
+	assert.Nil(t, writeBackupManifest(dir, manifest))
+
+	read, err := readBackupManifest(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, manifest, read)
+
+	records, err := readBackupChunk(dir, read.Chunks[0], false)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.JSONEq(t, `{"n":1}`, string(records[1].Value))
+
+	// tampered chunk.
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, read.Chunks[1].File), []byte("corrupted"), 0666))
+	_, err = readBackupChunk(dir, read.Chunks[1], false)
+	assert.EqualError(t, err, "chunk [chunk-00001.ndjson.gz] checksum mismatch, the backup is corrupted")
+}
+
+func TestRestoreBatchesPartition(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "lenses-backup", nil)
+	defer remove()
+
+	writer, err := newBackupWriter(dir, 10)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Write(backupRecord{Key: []byte(`"k1"`), Value: []byte(`{"n":1}`), Partition: 2, Offset: 0}))
+	assert.Nil(t, writer.Write(backupRecord{Key: []byte(`"k2"`), Value: []byte(`{"n":2}`), Partition: 0, Offset: 0}))
+	assert.Nil(t, writer.Close())
+
+	// same partitions, the records keep their partition.
+	records, err := readBackupChunk(dir, writer.chunks[0], true)
+	assert.Nil(t, err)
+
+	batches, err := newProduceBatches("payments", records, 10)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, `INSERT INTO payments(_key, _value, _meta.partition) VALUES('k1', '{"n":1}', 2), ('k2', '{"n":2}', 0)`, batches[0].Statement)
+
+	// different partitions, the records are partitioned by their key.
+	records, err = readBackupChunk(dir, writer.chunks[0], false)
+	assert.Nil(t, err)
+
+	batches, err = newProduceBatches("payments", records, 10)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, `INSERT INTO payments(_key, _value) VALUES('k1', '{"n":1}'), ('k2', '{"n":2}')`, batches[0].Statement)
+}
+
+func TestRestoreBatchesWholeValues(t *testing.T) {
+	dir, remove := test.TempLandscape(t, "lenses-backup", nil)
+	defer remove()
+
+	writer, err := newBackupWriter(dir, 10)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Write(backupRecord{Key: []byte(`{"id": 1}`), Value: []byte(`{}`)}))
+	assert.Nil(t, writer.Write(backupRecord{Key: []byte(`"k"`), Value: []byte(`[{"n": 1}, {"n": "it's"}]`)}))
+	assert.Nil(t, writer.Write(backupRecord{Key: []byte(`"k"`), Value: []byte(`null`)}))
+	assert.Nil(t, writer.Close())
+
+	records, err := readBackupChunk(dir, writer.chunks[0], false)
+	assert.Nil(t, err)
+
+	// an empty object is not a tombstone and objects inside arrays are kept as they are.
+	batches, err := newProduceBatches("payments", records, 10)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, `INSERT INTO payments(_key, _value) VALUES('{"id":1}', '{}'), ('k', '[{"n":1},{"n":"it''s"}]'), ('k', NULL)`, batches[0].Statement)
+}
+
+func TestBackupQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM `my-topic`", backupQuery("my-topic", "", ""))
+	assert.Equal(t, "SET max.size = '1GB'; SET max.query.time = '1h'; SELECT * FROM payments", backupQuery("payments", "1GB", "1h"))
+}
@@ -21,7 +21,7 @@ type topicView struct {
 
 //NewTopicsGroupCommand creates `topics` command
 func NewTopicsGroupCommand() *cobra.Command {
-	var namesOnly, unwrap bool
+	var namesOnly, unwrap, underReplicated bool
 
 	root := &cobra.Command{
 		Use:           "topics",
@@ -32,7 +32,15 @@ func NewTopicsGroupCommand() *cobra.Command {
 			client := config.Client
 
 			if namesOnly {
-				topicNames, err := client.GetTopicsNames()
+				var (
+					topicNames []string
+					err        error
+				)
+				if underReplicated {
+					topicNames, err = underReplicatedTopicsNames(client)
+				} else {
+					topicNames, err = client.GetTopicsNames()
+				}
 				if err != nil {
 					return err
 				}
@@ -54,6 +62,12 @@ func NewTopicsGroupCommand() *cobra.Command {
 				return err
 			}
 
+			if underReplicated {
+				if topics, err = underReplicatedTopics(client, topics); err != nil {
+					return err
+				}
+			}
+
 			sort.Slice(topics, func(i, j int) bool {
 				return topics[i].TopicName < topics[j].TopicName
 			})
@@ -72,6 +86,7 @@ func NewTopicsGroupCommand() *cobra.Command {
 
 	root.Flags().BoolVar(&namesOnly, "names", false, "Print topic names only")
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap")
+	root.Flags().BoolVar(&underReplicated, "under-replicated", false, "List only the topics with under-replicated partitions")
 
 	bite.CanPrintJSON(root)
 
@@ -276,7 +291,11 @@ func NewTopicGroupCommand() *cobra.Command {
 	// subcommands
 	root.AddCommand(NewTopicCreateCommand())
 	root.AddCommand(NewTopicDeleteCommand())
+	root.AddCommand(NewTopicDescribeCommand())
 	root.AddCommand(NewTopicUpdateCommand())
+	root.AddCommand(NewTopicProduceCommand())
+	root.AddCommand(NewTopicBackupCommand())
+	root.AddCommand(NewTopicRestoreCommand())
 
 	return root
 }
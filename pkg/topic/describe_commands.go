@@ -0,0 +1,142 @@
+package topic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/kataras/golog"
+	"github.com/lensesio/bite"
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// noLeader is the leader of a partition which has no leader.
+const noLeader = -1
+
+type partitionView struct {
+	Partition       int    `json:"partition" header:"Partition"`
+	Leader          string `json:"leader" header:"Leader"`
+	Replicas        []int  `json:"replicas" header:"Replicas"`
+	InSyncReplicas  []int  `json:"isr" header:"ISR"`
+	UnderReplicated bool   `json:"underReplicated" header:"Under Replicated"`
+	Begin           int64  `json:"begin" header:"Begin"`
+	End             int64  `json:"end" header:"End"`
+	Messages        int64  `json:"messages" header:"Messages"`
+}
+
+func newPartitionView(p api.PartitionMessage) partitionView {
+	var leader string
+	switch {
+	case p.Leader == nil:
+		leader = "unknown"
+	case *p.Leader == noLeader:
+		leader = "none"
+	default:
+		leader = strconv.Itoa(*p.Leader)
+	}
+
+	return partitionView{
+		Partition:       p.Partition,
+		Leader:          leader,
+		Replicas:        p.Replicas,
+		InSyncReplicas:  p.InSyncReplicas,
+		UnderReplicated: p.IsUnderReplicated(),
+		Begin:           p.Begin,
+		End:             p.End,
+		Messages:        p.Messages,
+	}
+}
+
+func newPartitionViews(topic api.Topic) []partitionView {
+	partitions := make([]api.PartitionMessage, len(topic.MessagesPerPartition))
+	copy(partitions, topic.MessagesPerPartition)
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Partition < partitions[j].Partition
+	})
+
+	views := make([]partitionView, len(partitions))
+	for i, p := range partitions {
+		views[i] = newPartitionView(p)
+	}
+
+	return views
+}
+
+//NewTopicDescribeCommand creates `topic describe` command
+func NewTopicDescribeCommand() *cobra.Command {
+	var topicName string
+
+	cmd := &cobra.Command{
+		Use:              "describe <name>",
+		Short:            "Describe the partitions of a topic: leader, replicas, in-sync replicas and offsets",
+		Example:          `topic describe payments or topic describe --name="payments" --output=json`,
+		Args:             cobra.MaximumNArgs(1),
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				topicName = args[0]
+			}
+
+			if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"name": topicName}); err != nil {
+				return err
+			}
+
+			topic, err := config.Client.GetTopic(topicName)
+			if err != nil {
+				golog.Errorf("Failed to retrieve topic [%s]. [%s]", topicName, err.Error())
+				return err
+			}
+
+			return bite.PrintObject(cmd, newPartitionViews(topic))
+		},
+	}
+
+	cmd.Flags().StringVar(&topicName, "name", "", "Topic name, instead of the argument")
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// underReplicatedTopics returns the topics with at least one under-replicated partition.
+// The topics listed without their partitions are retrieved one by one.
+func underReplicatedTopics(client *api.Client, topics []api.Topic) ([]api.Topic, error) {
+	var unhealthy []api.Topic
+
+	for _, topic := range topics {
+		if len(topic.MessagesPerPartition) == 0 && topic.Partitions > 0 {
+			t, err := client.GetTopic(topic.TopicName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve the partitions of topic [%s]. [%v]", topic.TopicName, err)
+			}
+			topic = t
+		}
+
+		if len(topic.UnderReplicatedPartitions()) > 0 {
+			unhealthy = append(unhealthy, topic)
+		}
+	}
+
+	return unhealthy, nil
+}
+
+func underReplicatedTopicsNames(client *api.Client) ([]string, error) {
+	topics, err := client.GetTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err = underReplicatedTopics(client, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = topic.TopicName
+	}
+
+	return names, nil
+}
@@ -0,0 +1,88 @@
+package topic
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/lensesio/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const paymentsTopicResponse = `{
+  "topicName": "payments",
+  "partitions": 2,
+  "replication": 3,
+  "messagesPerPartition": [
+    {"partition": 1, "messages": 5, "begin": 10, "end": 15, "leader": -1, "replicas": [1, 2, 3], "isr": [2]},
+    {"partition": 0, "messages": 20, "begin": 0, "end": 20, "leader": 1, "replicas": [1, 2, 3], "isr": [1, 2, 3]}
+  ]
+}`
+
+const refundsTopicResponse = `{
+  "topicName": "refunds",
+  "partitions": 1,
+  "replication": 1,
+  "messagesPerPartition": [{"partition": 0, "messages": 3, "begin": 0, "end": 3}]
+}`
+
+const topicsResponse = `[
+  {"topicName": "payments", "partitions": 2, "replication": 3},
+  {"topicName": "orders", "partitions": 1, "replication": 1, "messagesPerPartition": [
+    {"partition": 0, "messages": 1, "begin": 0, "end": 1, "leader": 1, "replicas": [1], "isr": [1]}
+  ]}
+]`
+
+func newTopicsHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/topics":
+			w.Write([]byte(topicsResponse))
+		case "/api/topics/payments":
+			w.Write([]byte(paymentsTopicResponse))
+		case "/api/topics/refunds":
+			w.Write([]byte(refundsTopicResponse))
+		default:
+			t.Errorf("unexpected request [%s %s]", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestTopicDescribeCommand(t *testing.T) {
+	_, teardown := test.UsingTestingClient(t, newTopicsHandler(t))
+	defer teardown()
+
+	cmd := test.WithOutput(NewTopicDescribeCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "payments")
+	assert.Nil(t, err)
+
+	var partitions []partitionView
+	assert.Nil(t, json.Unmarshal([]byte(output), &partitions))
+	assert.Equal(t, []partitionView{
+		{Partition: 0, Leader: "1", Replicas: []int{1, 2, 3}, InSyncReplicas: []int{1, 2, 3}, Begin: 0, End: 20, Messages: 20},
+		{Partition: 1, Leader: "none", Replicas: []int{1, 2, 3}, InSyncReplicas: []int{2}, UnderReplicated: true, Begin: 10, End: 15, Messages: 5},
+	}, partitions)
+
+	// without leader, replicas and isr the leader is unknown and the partition is not reported as under-replicated.
+	cmd = test.WithOutput(NewTopicDescribeCommand(), "json")
+	output, err = test.ExecuteCommand(cmd, "--name", "refunds")
+	assert.Nil(t, err)
+
+	partitions = nil
+	assert.Nil(t, json.Unmarshal([]byte(output), &partitions))
+	assert.Equal(t, []partitionView{{Partition: 0, Leader: "unknown", Begin: 0, End: 3, Messages: 3}}, partitions)
+
+	_, err = test.ExecuteCommand(NewTopicDescribeCommand())
+	assert.NotNil(t, err)
+}
+
+func TestTopicsUnderReplicatedCommand(t *testing.T) {
+	_, teardown := test.UsingTestingClient(t, newTopicsHandler(t))
+	defer teardown()
+
+	cmd := test.WithOutput(NewTopicsGroupCommand(), "json")
+	output, err := test.ExecuteCommand(cmd, "--under-replicated", "--names", "--unwrap")
+	assert.Nil(t, err)
+	assert.Equal(t, "payments\n", output)
+}
@@ -0,0 +1,578 @@
+package topic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lensesio/bite"
+	lsql "github.com/lensesio/lenses-go/pkg/sql"
+	"github.com/lensesio/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+)
+
+// The supported input formats of the `topic produce` command.
+const (
+	produceFormatJSON     = "json"
+	produceFormatCSV      = "csv"
+	produceFormatAvroJSON = "avro-json"
+)
+
+type (
+	// produceRecord is a single record to be written into a topic.
+	produceRecord struct {
+		Key     json.RawMessage   `json:"key,omitempty"`
+		Value   json.RawMessage   `json:"value"`
+		Headers map[string]string `json:"headers,omitempty"`
+
+		// line is the position of the record in the source, used on failure reports.
+		line int
+		// partition is the partition to write the record to, written through the `_meta.partition` column when set,
+		// otherwise the partition is picked by the key.
+		partition *int
+		// whole writes the key and the value as they are through the `_key` and `_value` columns,
+		// objects and arrays as their JSON text, instead of flattening their fields.
+		whole bool
+	}
+
+	// produceBatch is a group of records sent with a single `INSERT INTO` statement.
+	produceBatch struct {
+		Statement string
+		Records   []produceRecord
+	}
+
+	produceFailure struct {
+		FromLine int    `json:"fromLine" header:"From Line"`
+		ToLine   int    `json:"toLine" header:"To Line"`
+		Records  int    `json:"records" header:"Records"`
+		Reason   string `json:"reason" header:"Reason"`
+	}
+)
+
+//NewTopicProduceCommand creates `topic produce` command
+func NewTopicProduceCommand() *cobra.Command {
+	var (
+		file, format string
+		batchSize    int
+		rate         float64
+	)
+
+	cmd := &cobra.Command{
+		Use:              "produce",
+		Short:            "Produce records into a topic from a NDJSON, CSV or Avro-JSON file or the standard input",
+		Example:          `topic produce my-topic --file=./records.json --batch-size=100 --rate=500 or cat records.csv | topic produce my-topic --format=csv`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("one argument is required for the topic name")
+			}
+
+			topicName := args[0]
+
+			if batchSize <= 0 {
+				return fmt.Errorf("batch size must be greater than zero")
+			}
+
+			var in io.Reader = cmd.InOrStdin()
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+
+				if format == "" {
+					format = produceFormatFromFilename(file)
+				}
+			}
+
+			records, err := readProduceRecords(in, format)
+			if err != nil {
+				return err
+			}
+
+			if len(records) == 0 {
+				return fmt.Errorf("no records found to produce")
+			}
+
+			batches, err := newProduceBatches(topicName, records, batchSize)
+			if err != nil {
+				return err
+			}
+
+			failures := produceBatches(batches, rate, lsql.Execute)
+
+			failed := 0
+			for _, f := range failures {
+				failed += f.Records
+			}
+
+			if failed > 0 {
+				if err := bite.PrintObject(cmd, failures); err != nil {
+					return err
+				}
+
+				return fmt.Errorf("failed to produce [%d] of [%d] records into topic [%s]", failed, len(records), topicName)
+			}
+
+			return bite.PrintInfo(cmd, "Produced [%d] records into topic [%s] in [%d] batches", len(records), topicName, len(batches))
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "File to read the records from, defaults to the standard input")
+	cmd.Flags().StringVar(&format, "format", "", "Format of the records: json (newline delimited), csv or avro-json. Defaults to the file extension or json")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "Maximum number of records sent with a single INSERT statement")
+	cmd.Flags().Float64Var(&rate, "rate", 0, "Maximum number of records per second, zero means no limit")
+
+	bite.CanBeSilent(cmd)
+	bite.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func produceFormatFromFilename(filename string) string {
+	lower := strings.ToLower(filename)
+
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return produceFormatCSV
+	case strings.HasSuffix(lower, ".avro.json"), strings.HasSuffix(lower, ".avrojson"):
+		return produceFormatAvroJSON
+	default:
+		return produceFormatJSON
+	}
+}
+
+// produceBatches sends each batch with "exec" and sleeps between them to respect the "rate" records per second.
+// It does not stop on failures, the returned failures describe the records that were not produced.
+func produceBatches(batches []produceBatch, rate float64, exec func(string, websocket.LiveListener) error) (failures []produceFailure) {
+	started := time.Now()
+	sent := 0
+
+	for _, batch := range batches {
+		if err := exec(batch.Statement, nil); err != nil {
+			failures = append(failures, produceFailure{
+				FromLine: batch.Records[0].line,
+				ToLine:   batch.Records[len(batch.Records)-1].line,
+				Records:  len(batch.Records),
+				Reason:   err.Error(),
+			})
+		}
+
+		sent += len(batch.Records)
+
+		if rate > 0 {
+			expected := time.Duration(float64(sent) / rate * float64(time.Second))
+			if wait := expected - time.Since(started); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+	}
+
+	return
+}
+
+func readProduceRecords(r io.Reader, format string) ([]produceRecord, error) {
+	switch strings.ToLower(format) {
+	case "", produceFormatJSON:
+		return readJSONRecords(r)
+	case produceFormatCSV:
+		return readCSVRecords(r)
+	case produceFormatAvroJSON:
+		records, err := readJSONRecords(r)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range records {
+			if records[i].Key, err = unwrapAvroJSON(records[i].Key); err != nil {
+				return nil, fmt.Errorf("line [%d]: key: [%v]", records[i].line, err)
+			}
+
+			if records[i].Value, err = unwrapAvroJSON(records[i].Value); err != nil {
+				return nil, fmt.Errorf("line [%d]: value: [%v]", records[i].line, err)
+			}
+		}
+
+		return records, nil
+	default:
+		return nil, fmt.Errorf("unsupported format [%s], use one of: [%s, %s, %s]", format, produceFormatJSON, produceFormatCSV, produceFormatAvroJSON)
+	}
+}
+
+// readJSONRecords reads newline delimited JSON objects of `{"key": ..., "value": ..., "headers": {...}}`.
+func readJSONRecords(r io.Reader) ([]produceRecord, error) {
+	var records []produceRecord
+
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			var record produceRecord
+			if uerr := json.Unmarshal(b, &record); uerr != nil {
+				return nil, fmt.Errorf("line [%d]: unable to unmarshal the record: [%v]", line, uerr)
+			}
+			record.line = line
+			records = append(records, record)
+		}
+
+		if err == io.EOF {
+			return records, nil
+		}
+	}
+}
+
+// readCSVRecords reads CSV records with a "value" and optionally "key" and "headers.<name>" columns.
+func readCSVRecords(r io.Reader) ([]produceRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	hasValue := false
+	for _, column := range columns {
+		if column == "value" {
+			hasValue = true
+		}
+	}
+
+	if !hasValue {
+		return nil, fmt.Errorf("csv header should contain a [value] column, found [%s]", strings.Join(columns, ","))
+	}
+
+	var records []produceRecord
+	// the header is the first line.
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := produceRecord{line: line}
+
+		for i, cell := range row {
+			if i >= len(columns) {
+				return nil, fmt.Errorf("line [%d]: more cells than columns", line)
+			}
+
+			switch column := columns[i]; {
+			case column == "key":
+				record.Key = csvCellAsJSON(cell)
+			case column == "value":
+				record.Value = csvCellAsJSON(cell)
+			case strings.HasPrefix(column, "headers."):
+				if record.Headers == nil {
+					record.Headers = make(map[string]string)
+				}
+				record.Headers[strings.TrimPrefix(column, "headers.")] = cell
+			}
+		}
+
+		records = append(records, record)
+	}
+}
+
+func csvCellAsJSON(cell string) json.RawMessage {
+	if cell != "" && json.Valid([]byte(cell)) {
+		return json.RawMessage(cell)
+	}
+
+	b, _ := json.Marshal(cell)
+	return b
+}
+
+var avroPrimitiveTypes = []string{"null", "boolean", "int", "long", "float", "double", "bytes", "string"}
+
+// unwrapAvroJSON removes the union type wrappers of the Avro JSON encoding, e.g. `{"string": "value"}` becomes `"value"`.
+func unwrapAvroJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(unwrapAvroJSONValue(v))
+}
+
+func unwrapAvroJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if len(value) == 1 {
+			for typ, inner := range value {
+				if isAvroUnionBranch(typ) {
+					return unwrapAvroJSONValue(inner)
+				}
+			}
+		}
+
+		for k, inner := range value {
+			value[k] = unwrapAvroJSONValue(inner)
+		}
+		return value
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = unwrapAvroJSONValue(inner)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func isAvroUnionBranch(typ string) bool {
+	for _, primitive := range avroPrimitiveTypes {
+		if typ == primitive {
+			return true
+		}
+	}
+
+	return strings.Contains(typ, ".")
+}
+
+// newProduceBatches splits the records to batches of up to "batchSize" records,
+// consecutive records of a batch that share the same columns are sent with a single multi-row statement.
+func newProduceBatches(topicName string, records []produceRecord, batchSize int) ([]produceBatch, error) {
+	var (
+		batches []produceBatch
+		current produceBatch
+		columns []string
+		rows    []string
+	)
+
+	flush := func() {
+		if len(rows) == 0 {
+			return
+		}
+
+		current.Statement = fmt.Sprintf("INSERT INTO %s(%s) VALUES%s",
+			quoteSQLIdentifier(topicName), strings.Join(columns, ", "), strings.Join(rows, ", "))
+		batches = append(batches, current)
+
+		current = produceBatch{}
+		columns = nil
+		rows = nil
+	}
+
+	for _, record := range records {
+		recordColumns, values, err := insertColumns(record)
+		if err != nil {
+			return nil, fmt.Errorf("line [%d]: [%v]", record.line, err)
+		}
+
+		if len(rows) == batchSize || (len(rows) > 0 && strings.Join(recordColumns, ",") != strings.Join(columns, ",")) {
+			flush()
+		}
+
+		columns = recordColumns
+		rows = append(rows, "("+strings.Join(values, ", ")+")")
+		current.Records = append(current.Records, record)
+	}
+
+	flush()
+	return batches, nil
+}
+
+// insertColumns returns the `INSERT INTO` columns and their literal values of a record.
+func insertColumns(record produceRecord) (columns []string, values []string, err error) {
+	add := func(prefix string, raw json.RawMessage, objectPrefix string) error {
+		if len(raw) == 0 {
+			return nil
+		}
+
+		if record.whole {
+			literal, err := wholeSQLLiteral(raw)
+			if err != nil {
+				return err
+			}
+
+			columns = append(columns, prefix)
+			values = append(values, literal)
+			return nil
+		}
+
+		var v interface{}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			return err
+		}
+
+		if obj, ok := v.(map[string]interface{}); ok && len(obj) > 0 {
+			return flattenColumns(objectPrefix, obj, func(column string, value interface{}) error {
+				literal, err := sqlLiteral(value)
+				if err != nil {
+					return fmt.Errorf("%s: [%v]", column, err)
+				}
+
+				columns = append(columns, column)
+				values = append(values, literal)
+				return nil
+			})
+		}
+
+		literal, err := sqlLiteral(v)
+		if err != nil {
+			return err
+		}
+
+		columns = append(columns, prefix)
+		values = append(values, literal)
+		return nil
+	}
+
+	if err = add("_key", record.Key, "_key."); err != nil {
+		return
+	}
+
+	if err = add("_value", record.Value, ""); err != nil {
+		return
+	}
+
+	if len(columns) == 0 || (len(columns) == 1 && columns[0] == "_key") {
+		// tombstone.
+		columns = append(columns, "_value")
+		values = append(values, "NULL")
+	}
+
+	if record.partition != nil {
+		columns = append(columns, "_meta.partition")
+		values = append(values, strconv.Itoa(*record.partition))
+	}
+
+	headerNames := make([]string, 0, len(record.Headers))
+	for name := range record.Headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	for _, name := range headerNames {
+		columns = append(columns, "_headers."+quoteSQLIdentifier(name))
+		values = append(values, quoteSQLString(record.Headers[name]))
+	}
+
+	return
+}
+
+func flattenColumns(prefix string, obj map[string]interface{}, visit func(column string, value interface{}) error) error {
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		column := prefix + quoteSQLIdentifier(name)
+
+		if inner, ok := obj[name].(map[string]interface{}); ok && len(inner) > 0 {
+			if err := flattenColumns(column+".", inner, visit); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := visit(column, obj[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wholeSQLLiteral returns the literal of a JSON key or value, objects and arrays are written as their JSON text.
+func wholeSQLLiteral(raw json.RawMessage) (string, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return "", err
+	}
+
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		var b bytes.Buffer
+		if err := json.Compact(&b, raw); err != nil {
+			return "", err
+		}
+		return quoteSQLString(b.String()), nil
+	default:
+		return sqlLiteral(v)
+	}
+}
+
+func sqlLiteral(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if value {
+			return "true", nil
+		}
+		return "false", nil
+	case json.Number:
+		return value.String(), nil
+	case string:
+		return quoteSQLString(value), nil
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			if _, isObject := item.(map[string]interface{}); isObject {
+				return "", fmt.Errorf("objects inside arrays are not supported")
+			}
+
+			literal, err := sqlLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = literal
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		// only empty objects reach here.
+		return "NULL", nil
+	default:
+		return "", fmt.Errorf("unsupported value [%v]", v)
+	}
+}
+
+func quoteSQLString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+var plainSQLIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func quoteSQLIdentifier(name string) string {
+	if plainSQLIdentifier.MatchString(name) {
+		return name
+	}
+
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
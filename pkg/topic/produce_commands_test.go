@@ -0,0 +1,90 @@
+package topic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadJSONRecords(t *testing.T) {
+	in := `{"key": "k1", "value": {"name": "john", "address": {"city": "London"}}, "headers": {"source": "seed"}}
+
+{"key": 2, "value": "plain"}
+`
+	records, err := readProduceRecords(strings.NewReader(in), produceFormatJSON)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, 1, records[0].line)
+	assert.Equal(t, 3, records[1].line)
+	assert.Equal(t, "seed", records[0].Headers["source"])
+
+	batches, err := newProduceBatches("my-topic", records, 10)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, "INSERT INTO `my-topic`(_key, address.city, name, _headers.source) VALUES('k1', 'London', 'john', 'seed')", batches[0].Statement)
+	assert.Equal(t, "INSERT INTO `my-topic`(_key, _value) VALUES(2, 'plain')", batches[1].Statement)
+}
+
+func TestReadCSVRecords(t *testing.T) {
+	in := "key,value,headers.source\nk1,10,seed\nk2,it's,seed\n"
+
+	records, err := readProduceRecords(strings.NewReader(in), produceFormatCSV)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, 3, records[1].line)
+
+	batches, err := newProduceBatches("payments", records, 10)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, "INSERT INTO payments(_key, _value, _headers.source) VALUES('k1', 10, 'seed'), ('k2', 'it''s', 'seed')", batches[0].Statement)
+
+	_, err = readProduceRecords(strings.NewReader("key,name\nk1,john\n"), produceFormatCSV)
+	assert.NotNil(t, err)
+}
+
+func TestReadAvroJSONRecords(t *testing.T) {
+	in := `{"key": {"string": "k1"}, "value": {"name": {"string": "john"}, "age": {"int": 30}, "card": {"com.example.Card": {"number": "1234"}}}}`
+
+	records, err := readProduceRecords(strings.NewReader(in), produceFormatAvroJSON)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.JSONEq(t, `"k1"`, string(records[0].Key))
+	assert.JSONEq(t, `{"name": "john", "age": 30, "card": {"number": "1234"}}`, string(records[0].Value))
+}
+
+func TestNewProduceBatchesSize(t *testing.T) {
+	var records []produceRecord
+	for i := 1; i <= 5; i++ {
+		records = append(records, produceRecord{Key: []byte(`"k"`), Value: []byte(`1`), line: i})
+	}
+
+	batches, err := newProduceBatches("t", records, 2)
+	assert.Nil(t, err)
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[2].Records, 1)
+
+	// tombstone.
+	batches, err = newProduceBatches("t", []produceRecord{{Key: []byte(`"k"`), Value: []byte(`null`), line: 1}}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "INSERT INTO t(_key, _value) VALUES('k', NULL)", batches[0].Statement)
+}
+
+func TestProduceBatchesFailures(t *testing.T) {
+	batches := []produceBatch{
+		{Statement: "ok", Records: []produceRecord{{line: 1}, {line: 2}}},
+		{Statement: "fail", Records: []produceRecord{{line: 3}, {line: 4}}},
+	}
+
+	failures := produceBatches(batches, 0, func(sql string, _ websocket.LiveListener) error {
+		if sql == "fail" {
+			return errors.New("[ERROR]: [boom]")
+		}
+		return nil
+	})
+
+	assert.Len(t, failures, 1)
+	assert.Equal(t, produceFailure{FromLine: 3, ToLine: 4, Records: 2, Reason: "[ERROR]: [boom]"}, failures[0])
+}
@@ -0,0 +1,332 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+)
+
+const (
+	// BundleManifestFileName is the name of the manifest at the root of a landscape bundle.
+	BundleManifestFileName = "bundle.json"
+	// BundleSignatureFileName is the name of the base64 encoded signature of the bundle manifest, when the bundle is signed.
+	BundleSignatureFileName = "bundle.sig"
+)
+
+// BundleManifest lists the files of a landscape bundle,
+// "Files" maps the slash separated path of every file of the bundle to its SHA-256 checksum.
+type BundleManifest struct {
+	CreatedAt  time.Time         `json:"createdAt"`
+	CLIVersion string            `json:"cliVersion"`
+	Files      map[string]string `json:"files"`
+}
+
+// WriteBundle packs the files under the "basePaths" directories, or the "basePaths" files, of a landscape directory
+// into a gzipped tar file with their manifest, signed with the PEM encoded PKCS #8 private key of "signKeyFile" if not empty.
+func WriteBundle(bundlePath, landscapeDir string, basePaths []string, signKeyFile string) (BundleManifest, error) {
+	files, err := ChecksumFiles(landscapeDir, basePaths...)
+	if err != nil {
+		return BundleManifest{}, err
+	}
+
+	manifest := BundleManifest{CreatedAt: time.Now().UTC(), CLIVersion: api.BuildVersion, Files: files}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+
+	var signature []byte
+	if signKeyFile != "" {
+		if signature, err = signBundle(signKeyFile, manifestBytes); err != nil {
+			return manifest, err
+		}
+	}
+
+	if dir := filepath.Dir(bundlePath); dir != "" {
+		if err = CreateDirectory(dir); err != nil {
+			return manifest, err
+		}
+	}
+
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		return manifest, err
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, err := ioutil.ReadFile(filepath.Join(landscapeDir, filepath.FromSlash(name)))
+		if err != nil {
+			return manifest, err
+		}
+		if err = writeBundleFile(tw, name, b, manifest.CreatedAt); err != nil {
+			return manifest, err
+		}
+	}
+
+	if err = writeBundleFile(tw, BundleManifestFileName, manifestBytes, manifest.CreatedAt); err != nil {
+		return manifest, err
+	}
+
+	if signature != nil {
+		encoded := []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+		if err = writeBundleFile(tw, BundleSignatureFileName, encoded, manifest.CreatedAt); err != nil {
+			return manifest, err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return manifest, err
+	}
+	if err = gw.Close(); err != nil {
+		return manifest, err
+	}
+
+	return manifest, f.Close()
+}
+
+func writeBundleFile(tw *tar.Writer, name string, b []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(b)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(b)
+	return err
+}
+
+// ExtractBundle extracts a landscape bundle to "dir" and verifies its files, and its signature if "verifyKeyFile" is set.
+// It returns whether the bundle is signed.
+func ExtractBundle(bundlePath, dir, verifyKeyFile string) (BundleManifest, bool, error) {
+	var manifest BundleManifest
+
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return manifest, false, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return manifest, false, fmt.Errorf("invalid bundle [%s]. [%v]", bundlePath, err)
+	}
+	defer gr.Close()
+
+	var (
+		manifestBytes, signature []byte
+		checksums                = make(map[string]string)
+		tr                       = tar.NewReader(gr)
+	)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, false, fmt.Errorf("invalid bundle [%s]. [%v]", bundlePath, err)
+		}
+
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		name, err := bundleFileName(header)
+		if err != nil {
+			return manifest, false, fmt.Errorf("invalid bundle [%s]. [%v]", bundlePath, err)
+		}
+
+		if _, ok := checksums[name]; ok || (name == BundleManifestFileName && manifestBytes != nil) || (name == BundleSignatureFileName && signature != nil) {
+			return manifest, false, fmt.Errorf("invalid bundle [%s]. [file [%s] is packed more than once]", bundlePath, name)
+		}
+
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return manifest, false, fmt.Errorf("invalid bundle [%s]. [%v]", bundlePath, err)
+		}
+
+		switch name {
+		case BundleManifestFileName:
+			manifestBytes = b
+			continue
+		case BundleSignatureFileName:
+			signature = b
+			continue
+		}
+
+		sum := sha256.Sum256(b)
+		checksums[name] = hex.EncodeToString(sum[:])
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err = CreateDirectory(filepath.Dir(target)); err != nil {
+			return manifest, false, err
+		}
+		if err = ioutil.WriteFile(target, b, 0644); err != nil {
+			return manifest, false, err
+		}
+	}
+
+	if manifestBytes == nil {
+		return manifest, false, fmt.Errorf("invalid bundle [%s]. [no %s found]", bundlePath, BundleManifestFileName)
+	}
+
+	if verifyKeyFile != "" {
+		if signature == nil {
+			return manifest, false, fmt.Errorf("bundle [%s] is not signed", bundlePath)
+		}
+		if err = verifyBundle(verifyKeyFile, manifestBytes, signature); err != nil {
+			return manifest, true, fmt.Errorf("bundle [%s] failed the signature verification. [%v]", bundlePath, err)
+		}
+	}
+
+	if err = json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return manifest, signature != nil, fmt.Errorf("invalid bundle manifest [%s]. [%v]", bundlePath, err)
+	}
+
+	var changes []string
+	for name, sum := range manifest.Files {
+		current, ok := checksums[name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("[%s] was removed", name))
+		} else if current != sum {
+			changes = append(changes, fmt.Sprintf("[%s] was modified", name))
+		}
+	}
+
+	for name := range checksums {
+		if _, ok := manifest.Files[name]; !ok {
+			changes = append(changes, fmt.Sprintf("[%s] was added", name))
+		}
+	}
+
+	if len(changes) > 0 {
+		sort.Strings(changes)
+		return manifest, signature != nil, fmt.Errorf("bundle [%s] was tampered with, %s", bundlePath, strings.Join(changes, ", "))
+	}
+
+	return manifest, signature != nil, nil
+}
+
+// bundleFileName returns the slash separated path of a file of a bundle,
+// only regular files inside the bundle are allowed.
+func bundleFileName(header *tar.Header) (string, error) {
+	if header.Typeflag != tar.TypeReg {
+		return "", fmt.Errorf("[%s] is not a regular file", header.Name)
+	}
+
+	name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+	if name == "." || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") || strings.Contains(header.Name, "\\") {
+		return "", fmt.Errorf("[%s] is outside of the bundle", header.Name)
+	}
+
+	return name, nil
+}
+
+func signBundle(keyFile string, message []byte) ([]byte, error) {
+	block, err := readPEM(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key [%s], a PKCS #8 key is expected. [%v]", keyFile, err)
+	}
+
+	digest := sha256.Sum256(message)
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, message), nil
+	case *rsa.PrivateKey:
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		return k.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported private key [%s], an ed25519, RSA or ECDSA key is expected", keyFile)
+	}
+}
+
+func verifyBundle(keyFile string, message, encodedSignature []byte) error {
+	block, err := readPEM(keyFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key [%s], a PKIX key is expected. [%v]", keyFile, err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encodedSignature)))
+	if err != nil {
+		return fmt.Errorf("invalid signature. [%v]", err)
+	}
+
+	digest := sha256.Sum256(message)
+	var valid bool
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(k, message, signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		var sig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(signature, &sig); err == nil {
+			valid = ecdsa.Verify(k, digest[:], sig.R, sig.S)
+		}
+	default:
+		return fmt.Errorf("unsupported public key [%s], an ed25519, RSA or ECDSA key is expected", keyFile)
+	}
+
+	if !valid {
+		return fmt.Errorf("the signature does not match the public key [%s]", keyFile)
+	}
+
+	return nil
+}
+
+func readPEM(keyFile string) (*pem.Block, error) {
+	b, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found in [%s]", keyFile)
+	}
+
+	return block, nil
+}
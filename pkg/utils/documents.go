@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lensesio/lenses-go/pkg"
+	"gopkg.in/yaml.v2"
+)
+
+// DocumentAPIVersion is the `apiVersion` of the landscape documents.
+const DocumentAPIVersion = "landscape.lenses.io/v1"
+
+// DocumentKind is the `kind` of a landscape document and the resource directory its files belong to in the directory layout.
+type DocumentKind struct {
+	Kind string
+	Path string
+}
+
+// DocumentKinds are the kinds of the landscape documents, in the order they are written.
+var DocumentKinds = []DocumentKind{
+	{Kind: "Connection", Path: pkg.ConnectionsFilePath},
+	{Kind: "AlertChannel", Path: pkg.AlertChannelsFilePath},
+	{Kind: "AuditChannel", Path: pkg.AuditChannelsFilePath},
+	{Kind: "Topic", Path: pkg.TopicsPath},
+	{Kind: "Schema", Path: pkg.SchemasPath},
+	{Kind: "ACLList", Path: pkg.AclsPath},
+	{Kind: "QuotaList", Path: pkg.QuotasPath},
+	{Kind: "BrokerConfigs", Path: pkg.BrokerConfigsPath},
+	{Kind: "Group", Path: pkg.GroupsPath},
+	{Kind: "ServiceAccount", Path: pkg.ServiceAccountsPath},
+	{Kind: "Policy", Path: pkg.PoliciesPath},
+	{Kind: "Processor", Path: pkg.SQLPath},
+	{Kind: "Connector", Path: pkg.ConnectorsPath},
+	{Kind: "AlertSettings", Path: pkg.AlertSettingsPath},
+	{Kind: "Datasets", Path: pkg.DatasetsPath},
+	{Kind: "ConsumerOffsets", Path: pkg.ConsumerOffsetsPath},
+}
+
+// KindOfPath returns the kind of the documents of a resource directory.
+func KindOfPath(path string) (string, bool) {
+	for _, k := range DocumentKinds {
+		if k.Path == path {
+			return k.Kind, true
+		}
+	}
+	return "", false
+}
+
+// PathOfKind returns the resource directory of the documents of a kind.
+func PathOfKind(kind string) (string, bool) {
+	for _, k := range DocumentKinds {
+		if k.Kind == kind {
+			return k.Path, true
+		}
+	}
+	return "", false
+}
+
+func kindOrder(kind string) int {
+	for i, k := range DocumentKinds {
+		if k.Kind == kind {
+			return i
+		}
+	}
+	return len(DocumentKinds)
+}
+
+// DocumentMetadata identifies a landscape document,
+// "Name" is the name of the file of the resource in the directory layout, without its extension.
+type DocumentMetadata struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+// Document is a resource of a landscape with its `kind` and `apiVersion` headers,
+// "Spec" holds what the file of the resource holds in the directory layout.
+type Document struct {
+	APIVersion string           `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string           `json:"kind" yaml:"kind"`
+	Metadata   DocumentMetadata `json:"metadata" yaml:"metadata"`
+	Spec       interface{}      `json:"spec" yaml:"spec"`
+}
+
+// NewDocument returns the document of the file of a resource directory.
+func NewDocument(basePath, fileName string, resource interface{}) (Document, error) {
+	kind, ok := KindOfPath(basePath)
+	if !ok {
+		return Document{}, fmt.Errorf("no document kind for the resource directory [%s]", basePath)
+	}
+
+	name := strings.TrimSuffix(fileName, fileExt(fileName))
+	return Document{APIVersion: DocumentAPIVersion, Kind: kind, Metadata: DocumentMetadata{Name: name}, Spec: resource}, nil
+}
+
+func fileExt(fileName string) string {
+	if i := strings.LastIndex(fileName, "."); i > 0 {
+		return fileName[i:]
+	}
+	return ""
+}
+
+// MarshalDocuments returns the documents as a multi-document YAML file, sorted by kind and name.
+func MarshalDocuments(documents []Document) ([]byte, error) {
+	sorted := append([]Document{}, documents...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Kind != sorted[j].Kind {
+			return kindOrder(sorted[i].Kind) < kindOrder(sorted[j].Kind)
+		}
+		return sorted[i].Metadata.Name < sorted[j].Metadata.Name
+	})
+
+	var b bytes.Buffer
+	for i, document := range sorted {
+		y, err := yaml.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode document [%s/%s]. [%v]", document.Kind, document.Metadata.Name, err)
+		}
+
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(y)
+	}
+
+	return b.Bytes(), nil
+}
+
+var documentSeparatorExpr = regexp.MustCompile(`(?m)^---[ \t]*(#.*)?\r?$`)
+
+// SplitDocuments splits a multi-document YAML file on its `---` separators, the empty documents are left out.
+func SplitDocuments(b []byte) [][]byte {
+	var documents [][]byte
+	for _, document := range documentSeparatorExpr.Split(string(b), -1) {
+		if isBlankDocument(document) {
+			continue
+		}
+		documents = append(documents, []byte(document))
+	}
+	return documents
+}
+
+func isBlankDocument(document string) bool {
+	for _, line := range strings.Split(document, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// DocumentHeaders returns the `apiVersion` and the `kind` of a decoded landscape file, if it is a document.
+func DocumentHeaders(v interface{}) (apiVersion, kind string, ok bool) {
+	var headers map[string]interface{}
+	switch m := v.(type) {
+	case map[string]interface{}:
+		headers = m
+	case map[interface{}]interface{}:
+		headers = make(map[string]interface{}, len(m))
+		for k, value := range m {
+			headers[fmt.Sprint(k)] = value
+		}
+	default:
+		return "", "", false
+	}
+
+	apiVersion, hasAPIVersion := headers["apiVersion"].(string)
+	kind, hasKind := headers["kind"].(string)
+	return apiVersion, kind, hasAPIVersion && hasKind
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// JSONSchemaDraft is the `$schema` of the JSON Schemas returned by JSONSchemaOf.
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// JSONSchemaOf returns the strict JSON Schema of the files decoded to "v" by their "yaml" or "json" tags.
+func JSONSchemaOf(v interface{}, tag string) map[string]interface{} {
+	return jsonSchemaOf(reflect.TypeOf(v), tag, make(map[reflect.Type]bool))
+}
+
+func jsonSchemaOf(t reflect.Type, tag string, visiting map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	if decodesItself(t, tag) {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		if tag == "yaml" {
+			// YAML decodes any scalar to a string.
+			return map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+		}
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchemaOf(t.Elem(), tag, visiting)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchemaOf(t.Elem(), tag, visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			// a recursive type, e.g. a tree, is not described further.
+			return map[string]interface{}{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := make(map[string]interface{})
+		addStructProperties(t, tag, visiting, properties)
+		return map[string]interface{}{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func decodesItself(t reflect.Type, tag string) bool {
+	pt := reflect.PtrTo(t)
+	switch tag {
+	case "yaml":
+		return t.Implements(yamlUnmarshalerType) || pt.Implements(yamlUnmarshalerType)
+	default:
+		return t.Implements(jsonUnmarshalerType) || pt.Implements(jsonUnmarshalerType) ||
+			t.Implements(textUnmarshalerType) || pt.Implements(textUnmarshalerType)
+	}
+}
+
+// addStructProperties adds the properties of the fields of a struct the way `encoding/json` or `yaml.v2` name them.
+func addStructProperties(t reflect.Type, tag string, visiting map[reflect.Type]bool, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		value, tagged := field.Tag.Lookup(tag)
+		parts := strings.Split(value, ",")
+		name := parts[0]
+		if name == "-" && len(parts) == 1 {
+			continue
+		}
+
+		inline := false
+		for _, option := range parts[1:] {
+			inline = inline || option == "inline"
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && ((tag == "json" && field.Anonymous && name == "") || (tag == "yaml" && inline)) {
+			addStructProperties(fieldType, tag, visiting, properties)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if !tagged || name == "" {
+			name = field.Name
+			if tag == "yaml" {
+				name = strings.ToLower(name)
+			}
+		}
+
+		properties[name] = jsonSchemaOf(field.Type, tag, visiting)
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+)
+
+// ManifestFileName is the name of the manifest file written at the root of an exported landscape.
+const ManifestFileName = "manifest.json"
+
+// Manifest records where and when a landscape was exported from,
+// "Files" maps the slash separated path of every exported file, relative to the landscape directory, to its SHA-256 checksum.
+type Manifest struct {
+	Host          string            `json:"host" yaml:"host"`
+	LensesVersion string            `json:"lensesVersion" yaml:"lensesVersion"`
+	ExecutionMode api.ExecutionMode `json:"executionMode" yaml:"executionMode"`
+	ExportedAt    time.Time         `json:"exportedAt" yaml:"exportedAt"`
+	CLIVersion    string            `json:"cliVersion" yaml:"cliVersion"`
+	Files         map[string]string `json:"files" yaml:"files"`
+}
+
+// ChecksumFiles returns the SHA-256 checksums of the files under the "basePaths" directories, or of the "basePaths" files, of a landscape,
+// keyed by their slash separated path relative to the landscape directory. Missing directories are ignored.
+func ChecksumFiles(landscapeDir string, basePaths ...string) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	for _, basePath := range basePaths {
+		root := filepath.Join(landscapeDir, basePath)
+		info, err := os.Stat(root)
+		if err != nil {
+			continue
+		}
+
+		// a single file landscape, see `export --single-file`.
+		if !info.IsDir() {
+			sum, err := checksumFile(root)
+			if err != nil {
+				return nil, err
+			}
+			checksums[filepath.ToSlash(basePath)] = sum
+			continue
+		}
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			sum, err := checksumFile(path)
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(landscapeDir, path)
+			if err != nil {
+				return err
+			}
+
+			checksums[filepath.ToSlash(rel)] = sum
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return checksums, nil
+}
+
+func checksumFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteManifest writes the manifest to the root of the landscape directory.
+func WriteManifest(landscapeDir string, manifest Manifest) error {
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(landscapeDir, ManifestFileName), b, 0666)
+}
+
+// ReadManifest reads the manifest of a landscape directory, it returns false if the landscape has no manifest.
+func ReadManifest(landscapeDir string) (Manifest, bool, error) {
+	var manifest Manifest
+
+	b, err := ioutil.ReadFile(filepath.Join(landscapeDir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, false, nil
+		}
+		return manifest, false, err
+	}
+
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return manifest, false, fmt.Errorf("invalid manifest [%s]. [%v]", filepath.Join(landscapeDir, ManifestFileName), err)
+	}
+
+	return manifest, true, nil
+}
+
+// VerifyFiles compares the files under the "basePaths" directories of a landscape with the checksums of the manifest
+// and returns a description of every file that was modified, added or removed since the export, "basePaths" may also be files.
+func (m Manifest) VerifyFiles(landscapeDir string, basePaths ...string) ([]string, error) {
+	current, err := ChecksumFiles(landscapeDir, basePaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	inBasePaths := func(file string) bool {
+		for _, basePath := range basePaths {
+			if file == filepath.ToSlash(basePath) || strings.HasPrefix(file, filepath.ToSlash(basePath)+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	var changes []string
+	for file, sum := range m.Files {
+		if !inBasePaths(file) {
+			continue
+		}
+
+		currentSum, ok := current[file]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("[%s] was removed", file))
+		} else if currentSum != sum {
+			changes = append(changes, fmt.Sprintf("[%s] was modified", file))
+		}
+	}
+
+	for file := range current {
+		if _, ok := m.Files[file]; !ok {
+			changes = append(changes, fmt.Sprintf("[%s] was added", file))
+		}
+	}
+
+	sort.Strings(changes)
+	return changes, nil
+}
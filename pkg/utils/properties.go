@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// MarshalProperties writes the "properties" in the Java .properties format, one `key=value` line per property sorted by key.
+// The non ASCII characters are written as `\uXXXX` escapes, the .properties files are read as ISO 8859-1.
+func MarshalProperties(properties map[string]string) []byte {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	for _, key := range keys {
+		b.WriteString(escapeProperty(key, true))
+		b.WriteByte('=')
+		b.WriteString(escapeProperty(properties[key], false))
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes()
+}
+
+func escapeProperty(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (key || i == 0):
+			b.WriteString(`\ `)
+		case (r == '=' || r == ':') && key:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case (r == '#' || r == '!') && i == 0:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, u := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04x`, u)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// UnmarshalProperties reads the properties of a Java .properties file: the `#` and `!` comments, the `=`, `:` and
+// white space separators, the lines continued by a trailing backslash and the escapes, `\uXXXX` included.
+func UnmarshalProperties(b []byte) (map[string]string, error) {
+	properties := make(map[string]string)
+
+	lines := strings.Split(strings.Replace(string(b), "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// a line ending with an odd number of backslashes continues on the next one, without its leading white space.
+		for continues(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if continues(line) {
+			line = line[:len(line)-1]
+		}
+
+		key, value := splitProperty(line)
+		unescapedKey, err := unescapeProperty(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property at line [%d]. [%v]", lineNumber, err)
+		}
+		unescapedValue, err := unescapeProperty(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property [%s] at line [%d]. [%v]", unescapedKey, lineNumber, err)
+		}
+
+		properties[unescapedKey] = unescapedValue
+	}
+
+	return properties, nil
+}
+
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped separator, the value starts after the white space around it.
+func splitProperty(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if c := line[i]; c == '\\' {
+			i++
+		} else if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+
+	key, rest := line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return key, rest
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var (
+		b     strings.Builder
+		units []uint16
+	)
+	flush := func() {
+		if len(units) > 0 {
+			b.WriteString(string(utf16.Decode(units)))
+			units = units[:0]
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flush()
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		if s[i] == 'u' {
+			if i+5 > len(s) {
+				return "", fmt.Errorf("malformed \\u escape [%s]", s[i-1:])
+			}
+			u, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape [%s]", s[i-1:i+5])
+			}
+			units = append(units, uint16(u))
+			i += 4
+			continue
+		}
+
+		flush()
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	flush()
+
+	return b.String(), nil
+}
@@ -199,6 +199,42 @@ func RichLog(level string, log string) {
 	}
 }
 
+// systemTopicExclusions are the prefixes of the topics used internally by Kafka, Connect and Lenses
+var systemTopicExclusions = []string{
+	"connect-configs",
+	"connect-offsets",
+	"connect-status",
+	"connect-statuses",
+	"_schemas",
+	"__consumer_offsets",
+	"_kafka_lenses_",
+	"lsql_",
+	"__transaction_state",
+	"__topology",
+	"__topology__metrics",
+	"_connect-configs",
+	"_connect-status",
+	"_connect-offsets",
+	"_lenses_",
+}
+
+//IsSystemTopic checks if a topic, or a schema subject, is used internally by Kafka, Connect or Lenses
+func IsSystemTopic(name string) bool {
+	if strings.Contains(name, "KSTREAM-") ||
+		strings.Contains(name, "_agg_") ||
+		strings.Contains(name, "_sql_store_") {
+		return true
+	}
+
+	for _, exclude := range systemTopicExclusions {
+		if strings.HasPrefix(name, exclude) {
+			return true
+		}
+	}
+
+	return false
+}
+
 //StringInSlice check if a string is in slice
 func StringInSlice(str string, list []string) bool {
 	for _, v := range list {
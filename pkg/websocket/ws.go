@@ -68,9 +68,63 @@ type (
 		// Content contains the actual response content.
 		// Each response type has its own content layout.
 		Data Data `json:"data"`
+
+		// RawData is the response content as it was received,
+		// use it to decode layouts other than the record's `Data`, e.g. see `Stats`.
+		RawData json.RawMessage `json:"-"`
+	}
+
+	// Stats is the content of a "STATS" response, the query's progress so far.
+	Stats struct {
+		TotalRecords   int64            `json:"totalRecords"`
+		RecordsSkipped int64            `json:"recordsSkipped"`
+		RecordsLimit   int64            `json:"recordsLimit"`
+		TotalSizeRead  int64            `json:"totalSizeRead"`
+		Size           int64            `json:"size"`
+		Offsets        []PartitionStats `json:"offsets"`
+	}
+
+	// PartitionStats describes the read progress of a single partition, see `Stats`.
+	PartitionStats struct {
+		Partition int   `json:"partition"`
+		Begin     int64 `json:"begin"`
+		End       int64 `json:"end"`
+		Offset    int64 `json:"offset"`
 	}
 )
 
+// UnmarshalJSON decodes the response and keeps its raw content, see `RawData`.
+func (resp *LiveResponse) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type ResponseType    `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	resp.Type = raw.Type
+	resp.RawData = raw.Data
+
+	if len(raw.Data) == 0 || resp.Type == StatsResponse {
+		return nil
+	}
+
+	return json.Unmarshal(raw.Data, &resp.Data)
+}
+
+// Stats decodes the content of a "STATS" response.
+func (resp LiveResponse) Stats() (stats Stats, err error) {
+	if resp.Type != StatsResponse {
+		err = fmt.Errorf("live: [%s] response does not contain stats", resp.Type)
+		return
+	}
+
+	err = json.Unmarshal(resp.RawData, &stats)
+	return
+}
+
 type (
 	//Message for WS
 	Message struct {
@@ -232,7 +286,11 @@ func (c *LiveConnection) Err() <-chan error {
 
 func (c *LiveConnection) sendErr(err error) {
 	golog.Debug(err)
-	c.errors <- err
+	// don't block the reader forever if nobody listens anymore, i.e. after `Close`.
+	select {
+	case c.errors <- err:
+	case <-c.receiveStop:
+	}
 }
 
 func (c *LiveConnection) readLoop() {
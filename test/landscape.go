@@ -0,0 +1,58 @@
+package test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lensesio/lenses-go/pkg/api"
+	config "github.com/lensesio/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+//TempLandscape creates a temporary directory with the files, keyed by their slash separated paths
+func TempLandscape(t *testing.T, prefix string, files map[string]string) (string, func()) {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	WriteLandscapeFiles(t, dir, files)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+//WriteLandscapeFiles writes the files, keyed by their slash separated paths, to a directory
+func WriteLandscapeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0666))
+	}
+}
+
+//UsingTestingClient sets the client of the commands to a client of the handler, the teardown resets it
+func UsingTestingClient(t *testing.T, handler http.Handler) (*api.Client, func()) {
+	httpClient, closeServer := TestingHTTPClient(handler)
+
+	client, err := api.OpenConnection(ClientConfig, api.UsingClient(httpClient))
+	if err != nil {
+		closeServer()
+		t.Fatal(err)
+	}
+
+	config.Client = client
+	return client, func() {
+		config.Client = nil
+		closeServer()
+	}
+}
+
+//WithOutput adds the `--output` flag of the root command to a command
+func WithOutput(cmd *cobra.Command, output string) *cobra.Command {
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", output, "")
+	return cmd
+}